@@ -0,0 +1,149 @@
+package openai
+
+// Check if AssistantRequest implements Requester interface.
+var _ Requester = (*AssistantRequest)(nil)
+
+// assistantsBeta is the value of the OpenAI-Beta header
+// required by the Assistants API.
+const assistantsBeta = "assistants=v2"
+
+const (
+	assistantMaxName         = 256
+	assistantMaxDescription  = 512
+	assistantMaxInstructions = 256000
+	assistantMaxTools        = 128
+)
+
+// AssistantRequest represents a request to create
+// or modify an assistant with the OpenAI Assistants API.
+type AssistantRequest struct {
+	// ID of the model to use. This is required to create an assistant.
+	Model string `json:"model,omitempty"`
+
+	// The name of the assistant, the maximum length is 256 characters.
+	Name string `json:"name,omitempty"`
+
+	// The description of the assistant,
+	// the maximum length is 512 characters.
+	Description string `json:"description,omitempty"`
+
+	// The system instructions that the assistant uses,
+	// the maximum length is 256,000 characters.
+	Instructions string `json:"instructions,omitempty"`
+
+	// A list of tools enabled on the assistant,
+	// there can be a maximum of 128 tools.
+	Tools []Tool `json:"tools,omitempty"`
+
+	// A list of file IDs attached to the assistant.
+	FileIDs []string `json:"file_ids,omitempty"`
+
+	// Set of key-value pairs that can be attached to the assistant.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// AssistantResponse represents an assistant object
+// returned by the OpenAI Assistants API.
+type AssistantResponse struct {
+	// The identifier of the assistant.
+	ID string `json:"id"`
+
+	// The object type, which is always "assistant".
+	Object string `json:"object"`
+
+	// The Unix timestamp (in seconds) when the assistant was created.
+	CreatedAt int64 `json:"created_at"`
+
+	// The name of the assistant.
+	Name string `json:"name"`
+
+	// The description of the assistant.
+	Description string `json:"description"`
+
+	// ID of the model used by the assistant.
+	Model string `json:"model"`
+
+	// The system instructions that the assistant uses.
+	Instructions string `json:"instructions"`
+
+	// A list of tools enabled on the assistant.
+	Tools []Tool `json:"tools"`
+
+	// A list of file IDs attached to the assistant.
+	FileIDs []string `json:"file_ids"`
+
+	// Set of key-value pairs attached to the assistant.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// AssistantDeleteResponse represents the response
+// from the OpenAI Assistants API when an assistant is deleted.
+type AssistantDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+// AssistantsData represents a list of assistants.
+type AssistantsData []*AssistantResponse
+
+// AssistantListResponse represents the response from
+// the OpenAI Assistants API with a list of assistants.
+type AssistantListResponse struct {
+	// The object type, which is always "list".
+	Object string `json:"object"`
+
+	// A list of assistants.
+	Data AssistantsData `json:"data"`
+
+	// The ID of the first assistant in the list.
+	FirstID string `json:"first_id"`
+
+	// The ID of the last assistant in the list,
+	// it can be used as a cursor for the next page.
+	LastID string `json:"last_id"`
+
+	// Whether there are more assistants to fetch.
+	HasMore bool `json:"has_more"`
+}
+
+// Error returns an error if the request is invalid.
+// The model isn't checked here because it's optional
+// for modifying an assistant.
+func (r *AssistantRequest) Error() error {
+	if len(r.Name) > assistantMaxName ||
+		len(r.Description) > assistantMaxDescription ||
+		len(r.Instructions) > assistantMaxInstructions {
+		return ErrInputTooLong
+	}
+
+	if len(r.Tools) > assistantMaxTools {
+		return ErrTooManyTools
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *AssistantRequest) Flush() {
+}
+
+// Range returns the assistants list.
+func (data *AssistantsData) Range() AssistantsData {
+	return *data
+}
+
+// Len returns the length of the assistants list.
+func (data *AssistantsData) Len() int {
+	return len(*data)
+}
+
+// Names returns a list of the names of the assistants.
+func (data *AssistantsData) Names() []string {
+	names := make([]string, data.Len())
+	for i, a := range *data {
+		names[i] = a.Name
+	}
+	return names
+}
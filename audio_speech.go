@@ -0,0 +1,245 @@
+package openai
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goloop/g"
+)
+
+// Check if AudioSpeechRequest implements Requester interface.
+var _ Requester = (*AudioSpeechRequest)(nil)
+
+// audioSpeechMaxInput is the maximum number of characters
+// that can be passed to the TTS endpoint in a single request.
+const audioSpeechMaxInput = 4096
+
+var (
+	validSpeechVoices = []string{
+		"alloy", "echo", "fable", "onyx", "nova", "shimmer",
+	}
+	validSpeechResponseFormats = []string{
+		"mp3", "opus", "aac", "flac", "wav", "pcm",
+	}
+)
+
+// AudioSpeechRequest represents a request to the OpenAI Speech API.
+type AudioSpeechRequest struct {
+	// The model ID to use for the request: tts-1 or tts-1-hd.
+	// This is required.
+	Model string `json:"model"`
+
+	// The text to generate audio for. The maximum length
+	// is 4096 characters. This is required.
+	Input string `json:"input"`
+
+	// The voice to use when generating the audio. Supported voices are
+	// alloy, echo, fable, onyx, nova, and shimmer. This is required.
+	Voice string `json:"voice"`
+
+	// The format of the audio output. Options include: mp3, opus,
+	// aac, flac, wav, or pcm. Defaults to mp3 if not specified.
+	ResponseFormat string `json:"response_format,omitempty"`
+
+	// The speed of the generated audio. Select a value from 0.25 to 4.0.
+	// Defaults to 1.0 if not specified.
+	Speed float64 `json:"speed,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
+}
+
+// Error returns an error if the request is invalid.
+func (r *AudioSpeechRequest) Error() error {
+	if r.Model == "" {
+		return ErrModelRequired
+	}
+
+	if r.Input == "" {
+		return ErrInputRequired
+	}
+
+	if len(r.Input) > audioSpeechMaxInput {
+		return ErrInputTooLong
+	}
+
+	if !g.In(r.Voice, validSpeechVoices...) {
+		return ErrInvalidVoice
+	}
+
+	if r.ResponseFormat != "" &&
+		!g.In(r.ResponseFormat, validSpeechResponseFormats...) {
+		return ErrInvalidResponseFormat
+	}
+
+	if r.Speed != 0 && (r.Speed < 0.25 || r.Speed > 4.0) {
+		return ErrInvalidSpeed
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *AudioSpeechRequest) Flush() {
+}
+
+// The timeout returns the timeout of the request.
+func (r *AudioSpeechRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// AudioSpeechResponse is the audio data returned by the OpenAI Speech API.
+//
+// Example usage:
+//
+//	data, err := client.AudioSpeech(r)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	err = openai.AudioSpeechResponse(data).Save("speech/hello.mp3")
+type AudioSpeechResponse []byte
+
+// Save writes the audio data to the file at the specified path.
+// The parent directories are created if necessary.
+func (r AudioSpeechResponse) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, bytes.NewReader(r))
+}
+
+// SplitSentences is the default splitter for long speech input.
+// It breaks the text on sentence boundaries (periods and newlines)
+// and packs the sentences into chunks that fit into the limit
+// of the TTS endpoint. A sentence that doesn't fit into the limit
+// by itself is cut on a rune boundary.
+func SplitSentences(input string) []string {
+	var (
+		chunks   []string
+		sentence strings.Builder
+		chunk    strings.Builder
+	)
+
+	// The push appends the sentence to the current chunk, or starts
+	// a new chunk if the sentence doesn't fit into the current one.
+	push := func(s string) {
+		for len(s) > audioSpeechMaxInput {
+			// Cut the oversized sentence on a rune boundary.
+			cut := audioSpeechMaxInput
+			for cut > 0 && !utf8.RuneStart(s[cut]) {
+				cut--
+			}
+
+			if chunk.Len() > 0 {
+				chunks = append(chunks, chunk.String())
+				chunk.Reset()
+			}
+
+			chunks = append(chunks, s[:cut])
+			s = s[cut:]
+		}
+
+		if chunk.Len()+len(s) > audioSpeechMaxInput {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+
+		chunk.WriteString(s)
+	}
+
+	for _, r := range input {
+		sentence.WriteRune(r)
+		if r == '.' || r == '\n' {
+			push(sentence.String())
+			sentence.Reset()
+		}
+	}
+
+	if sentence.Len() > 0 {
+		push(sentence.String())
+	}
+
+	if chunk.Len() > 0 {
+		chunks = append(chunks, chunk.String())
+	}
+
+	// Drop the chunks that contain nothing to synthesize.
+	result := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		if strings.TrimSpace(c) != "" {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// ConcatAudioChunks concatenates the audio chunks returned by the
+// AudioSpeechLong method into a single audio stream of the given format.
+//
+// The PCM data is concatenated as is. The MP3 data is concatenated
+// frame by frame, the ID3 tags of all chunks except the first one
+// are stripped so that the players don't treat them as separate tracks.
+// Other formats are not supported and ErrUnsupportedAudioFormat is returned.
+func ConcatAudioChunks(chunks [][]byte, format string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case "pcm":
+		for _, chunk := range chunks {
+			buf.Write(chunk)
+		}
+	case "mp3", "":
+		for i, chunk := range chunks {
+			if i > 0 {
+				chunk = stripID3Tags(chunk)
+			}
+			buf.Write(chunk)
+		}
+	default:
+		return nil, ErrUnsupportedAudioFormat
+	}
+
+	return buf.Bytes(), nil
+}
+
+// The stripID3Tags removes the ID3v2 header from the beginning
+// and the ID3v1 tag from the end of the MP3 data.
+func stripID3Tags(data []byte) []byte {
+	// ID3v2: "ID3", version (2 bytes), flags (1 byte) and the size
+	// (4 bytes) encoded as a synchsafe integer, 10 bytes total.
+	if len(data) >= 10 && bytes.HasPrefix(data, []byte("ID3")) {
+		size := int(data[6]&0x7f)<<21 |
+			int(data[7]&0x7f)<<14 |
+			int(data[8]&0x7f)<<7 |
+			int(data[9]&0x7f)
+
+		// The size doesn't include the header itself,
+		// and the footer flag adds 10 more bytes to the tag.
+		size += 10
+		if data[5]&0x10 != 0 {
+			size += 10
+		}
+
+		if size > len(data) {
+			size = len(data)
+		}
+		data = data[size:]
+	}
+
+	// ID3v1: the last 128 bytes starting with "TAG".
+	n := len(data)
+	if n >= 128 && bytes.HasPrefix(data[n-128:], []byte("TAG")) {
+		data = data[:n-128]
+	}
+
+	return data
+}
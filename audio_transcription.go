@@ -1,6 +1,27 @@
 package openai
 
-import "os"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goloop/g"
+)
+
+// maxAudioFileSize is the maximum size of the audio file
+// that can be transcribed or translated, 25 MB.
+const maxAudioFileSize = 25 << 20
+
+// audioModelWhisper1 is the model of the transcription shortcuts.
+const audioModelWhisper1 = "whisper-1"
+
+var validAudioFormats = []string{
+	"mp3", "mp4", "mpeg", "mpga", "m4a", "wav", "webm",
+}
 
 // AudioTranscriptionRequest represents a request
 // to the OpenAI Transcription API.
@@ -30,6 +51,17 @@ type AudioTranscriptionRequest struct {
 	// The language of the input audio. Supplying the input language
 	// in ISO-639-1 format will improve accuracy and latency.
 	Language string `json:"language,omitempty"`
+
+	// The timestamp granularities of the verbose_json transcription:
+	// word, segment or both. Optional, segment by default.
+	TimestampGranularities []string `json:"timestamp_granularities[],omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
+
+	// The temporary files to remove on Flush.
+	tempFiles []string
 }
 
 // AudioTranscriptionResponse represents a response from
@@ -37,6 +69,51 @@ type AudioTranscriptionRequest struct {
 type AudioTranscriptionResponse struct {
 	// The text transcription of the audio file.
 	Text string `json:"text"`
+
+	// The detailed transcription, it is set only
+	// if the response format is verbose_json.
+	Verbose *VerboseAudioTranscriptionResponse `json:"-"`
+}
+
+// WordTimestamp is the timing of a transcribed word.
+type WordTimestamp struct {
+	Word  string  `json:"word"`  // the text of the word
+	Start float64 `json:"start"` // start time of the word in seconds
+	End   float64 `json:"end"`   // end time of the word in seconds
+}
+
+// TranscriptionSegment is a segment of the transcribed text.
+type TranscriptionSegment struct {
+	ID               int     `json:"id"`                // unique identifier of the segment
+	Seek             int     `json:"seek"`              // seek offset of the segment
+	Start            float64 `json:"start"`             // start time of the segment in seconds
+	End              float64 `json:"end"`               // end time of the segment in seconds
+	Text             string  `json:"text"`              // text content of the segment
+	Tokens           []int   `json:"tokens"`            // token IDs of the text content
+	Temperature      float64 `json:"temperature"`       // temperature used to generate the segment
+	AvgLogProb       float64 `json:"avg_logprob"`       // average log probability of the segment
+	CompressionRatio float64 `json:"compression_ratio"` // compression ratio of the segment
+	NoSpeechProb     float64 `json:"no_speech_prob"`    // probability of no speech in the segment
+}
+
+// VerboseAudioTranscriptionResponse represents the response from the
+// OpenAI Transcription API with the verbose_json response format.
+type VerboseAudioTranscriptionResponse struct {
+	// The language of the input audio.
+	Language string `json:"language"`
+
+	// The duration of the input audio in seconds.
+	Duration float64 `json:"duration"`
+
+	// The text transcription of the audio file.
+	Text string `json:"text"`
+
+	// The segments of the transcribed text.
+	Segments []TranscriptionSegment `json:"segments,omitempty"`
+
+	// The timings of the transcribed words, they are returned only
+	// if the word timestamp granularity is requested.
+	Words []WordTimestamp `json:"words,omitempty"`
 }
 
 // Error returns an error if the request is invalid.
@@ -45,6 +122,10 @@ func (r *AudioTranscriptionRequest) Error() error {
 		return ErrFileRequired
 	}
 
+	if err := validateAudioFile(r.File); err != nil {
+		return err
+	}
+
 	if r.Model == "" {
 		return ErrModelRequired
 	}
@@ -66,6 +147,33 @@ func (r *AudioTranscriptionRequest) OpenAudioFile(path string) error {
 	return nil
 }
 
+// SetAudioReader writes the audio from the reader to a temporary file
+// and assigns it to the File field of the request. The filename is
+// used to detect the audio format, e.g. "speech.mp3". The temporary
+// file is removed on Flush.
+func (r *AudioTranscriptionRequest) SetAudioReader(
+	reader io.Reader,
+	filename string,
+) error {
+	r.CloseAudioFile()
+	file, err := createTempFile(reader, filename)
+	if err != nil {
+		return err
+	}
+
+	r.File = file
+	r.tempFiles = append(r.tempFiles, file.Name())
+	return nil
+}
+
+// SetAudioBytes is like SetAudioReader, but takes the audio data.
+func (r *AudioTranscriptionRequest) SetAudioBytes(
+	data []byte,
+	filename string,
+) error {
+	return r.SetAudioReader(bytes.NewReader(data), filename)
+}
+
 // CloseAudioFile closes the audio file associated with the request.
 func (r *AudioTranscriptionRequest) CloseAudioFile() {
 	if r.File != nil {
@@ -73,7 +181,51 @@ func (r *AudioTranscriptionRequest) CloseAudioFile() {
 	}
 }
 
-// Flush closes the files descriptors associated with the request.
+// Flush closes the files descriptors associated with the request
+// and removes the temporary files created by the request.
 func (r *AudioTranscriptionRequest) Flush() {
 	r.CloseAudioFile()
+	removeTempFiles(r.tempFiles)
+	r.tempFiles = nil
+}
+
+// The timeout returns the timeout of the request.
+func (r *AudioTranscriptionRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// WordTimestamps returns the timings of the transcribed words. It returns
+// nil if the response format isn't verbose_json or the word timestamp
+// granularity isn't requested.
+func (r *AudioTranscriptionResponse) WordTimestamps() []WordTimestamp {
+	if r.Verbose == nil {
+		return nil
+	}
+
+	return r.Verbose.Words
+}
+
+// The validateAudioFile returns an error if the format of the audio
+// file isn't supported by the API or the file is too large.
+func validateAudioFile(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(info.Name()), "."))
+	if !g.In(ext, validAudioFormats...) {
+		return fmt.Errorf("%w: %q", ErrInvalidAudioFormat, ext)
+	}
+
+	if info.Size() > maxAudioFileSize {
+		return fmt.Errorf(
+			"%w: %d bytes, the limit is %d bytes",
+			ErrAudioFileTooLarge,
+			info.Size(),
+			maxAudioFileSize,
+		)
+	}
+
+	return nil
 }
@@ -1,6 +1,11 @@
 package openai
 
-import "os"
+import (
+	"bytes"
+	"io"
+	"os"
+	"time"
+)
 
 // AudioTranslationRequest represents a request to the OpenAI Translation API.
 type AudioTranslationRequest struct {
@@ -26,6 +31,13 @@ type AudioTranslationRequest struct {
 	// use log probability to automatically increase the temperature until
 	// certain thresholds are hit.
 	Temperature float64 `json:"temperature,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
+
+	// The temporary files to remove on Flush.
+	tempFiles []string
 }
 
 // AudioTranslationResponse represents the response from the OpenAI
@@ -41,6 +53,10 @@ func (r *AudioTranslationRequest) Error() error {
 		return ErrFileRequired
 	}
 
+	if err := validateAudioFile(r.File); err != nil {
+		return err
+	}
+
 	if r.Model == "" {
 		return ErrModelRequired
 	}
@@ -62,6 +78,33 @@ func (r *AudioTranslationRequest) OpenAudioFile(path string) error {
 	return nil
 }
 
+// SetAudioReader writes the audio from the reader to a temporary file
+// and assigns it to the File field of the request. The filename is
+// used to detect the audio format, e.g. "speech.mp3". The temporary
+// file is removed on Flush.
+func (r *AudioTranslationRequest) SetAudioReader(
+	reader io.Reader,
+	filename string,
+) error {
+	r.CloseAudioFile()
+	file, err := createTempFile(reader, filename)
+	if err != nil {
+		return err
+	}
+
+	r.File = file
+	r.tempFiles = append(r.tempFiles, file.Name())
+	return nil
+}
+
+// SetAudioBytes is like SetAudioReader, but takes the audio data.
+func (r *AudioTranslationRequest) SetAudioBytes(
+	data []byte,
+	filename string,
+) error {
+	return r.SetAudioReader(bytes.NewReader(data), filename)
+}
+
 // CloseAudioFile closes the audio file associated with the request.
 func (r *AudioTranslationRequest) CloseAudioFile() {
 	if r.File != nil {
@@ -69,7 +112,15 @@ func (r *AudioTranslationRequest) CloseAudioFile() {
 	}
 }
 
-// Flush closes the files descriptors associated with the request.
+// Flush closes the files descriptors associated with the request
+// and removes the temporary files created by the request.
 func (r *AudioTranslationRequest) Flush() {
 	r.CloseAudioFile()
+	removeTempFiles(r.tempFiles)
+	r.tempFiles = nil
+}
+
+// The timeout returns the timeout of the request.
+func (r *AudioTranslationRequest) timeout() time.Duration {
+	return r.Timeout
 }
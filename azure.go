@@ -0,0 +1,78 @@
+package openai
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// azureAPIVersion is the default API version of the Azure OpenAI Service.
+const azureAPIVersion = "2024-02-01"
+
+// AzureConfig represents the configuration of the client
+// for the Azure OpenAI Service.
+type AzureConfig struct {
+	ResourceName string // name of the Azure OpenAI resource
+	DeploymentID string // name of the model deployment
+	APIVersion   string // API version, 2024-02-01 by default
+	APIKey       string // secret key of the resource
+}
+
+// NewAzureClient creates a new client for the Azure OpenAI Service.
+// The requests are sent to the deployment of the resource, so the
+// model of the requests is defined by the deployment. The requests
+// are authorized with the api-key header and have the api-version
+// query parameter.
+//
+// Example usage:
+//
+//	client, err := openai.NewAzureClient(openai.AzureConfig{
+//	    ResourceName: "my-resource",
+//	    DeploymentID: "gpt-4o",
+//	    APIKey:       "api-key",
+//	})
+func NewAzureClient(cfg AzureConfig) (*Client, error) {
+	if cfg.ResourceName == "" {
+		return nil, ErrResourceRequired
+	}
+
+	if cfg.DeploymentID == "" {
+		return nil, ErrDeploymentRequired
+	}
+
+	apiBaseURL := fmt.Sprintf(
+		"https://%s.openai.azure.com/openai/deployments/%s",
+		cfg.ResourceName,
+		cfg.DeploymentID,
+	)
+
+	cli, err := NewClient(
+		WithAPIKey(cfg.APIKey),
+		WithAPIBaseURL(apiBaseURL),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cli.azureAPIVersion = cfg.APIVersion
+	if cli.azureAPIVersion == "" {
+		cli.azureAPIVersion = azureAPIVersion
+	}
+
+	return cli, nil
+}
+
+// The authorize sets the api-key header and the api-version
+// query parameter for the Azure OpenAI Service requests.
+func (c *Client) authorize(req *http.Request) bool {
+	if c.azureAPIVersion == "" {
+		return false
+	}
+
+	req.Header.Set("api-key", c.apiKey)
+
+	query := req.URL.Query()
+	query.Set("api-version", c.azureAPIVersion)
+	req.URL.RawQuery = query.Encode()
+
+	return true
+}
@@ -0,0 +1,117 @@
+package openai
+
+import "github.com/goloop/g"
+
+// Check if BatchCreateRequest implements Requester interface.
+var _ Requester = (*BatchCreateRequest)(nil)
+
+// batchCompletionWindow is the only completion
+// window currently supported by the Batch API.
+const batchCompletionWindow = "24h"
+
+// validBatchEndpoints is a list of endpoints
+// that can be used for the requests of the batch.
+var validBatchEndpoints = []string{
+	"/v1/chat/completions",
+	"/v1/embeddings",
+	"/v1/completions",
+}
+
+// BatchCreateRequest represents a request to create
+// a batch with the OpenAI Batch API.
+type BatchCreateRequest struct {
+	// The ID of an uploaded JSONL file with the requests of the batch,
+	// the file must be uploaded with the "batch" purpose. This is required.
+	InputFileID string `json:"input_file_id"`
+
+	// The endpoint to be used for all requests in the batch, e.g.
+	// "/v1/chat/completions". This is required.
+	Endpoint string `json:"endpoint"`
+
+	// The time frame within which the batch should be processed.
+	// Currently only "24h" is supported, it's used if empty.
+	CompletionWindow string `json:"completion_window"`
+
+	// Set of key-value pairs that can be attached to the batch.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// BatchRequestInput is a single line of the batch input file.
+type BatchRequestInput struct {
+	// A developer-provided ID to match the outputs to the inputs,
+	// it must be unique for each request in the batch.
+	CustomID string `json:"custom_id"`
+
+	// The HTTP method of the request, currently only "POST".
+	Method string `json:"method"`
+
+	// The relative URL of the endpoint, e.g. "/v1/chat/completions".
+	URL string `json:"url"`
+
+	// The body of the request.
+	Body interface{} `json:"body"`
+}
+
+// BatchRequestCounts is the request counts
+// for different statuses within the batch.
+type BatchRequestCounts struct {
+	Total     int `json:"total"`     // total number of requests
+	Completed int `json:"completed"` // number of completed requests
+	Failed    int `json:"failed"`    // number of failed requests
+}
+
+// BatchResponse represents a batch object returned by the OpenAI Batch API.
+type BatchResponse struct {
+	ID               string             `json:"id"`                // identifier of the batch
+	Object           string             `json:"object"`            // always "batch"
+	Endpoint         string             `json:"endpoint"`          // endpoint of the requests
+	InputFileID      string             `json:"input_file_id"`     // ID of the input file
+	CompletionWindow string             `json:"completion_window"` // processing time frame
+	Status           string             `json:"status"`            // status of the batch
+	OutputFileID     string             `json:"output_file_id"`    // ID of the file with outputs
+	ErrorFileID      string             `json:"error_file_id"`     // ID of the file with errors
+	CreatedAt        int64              `json:"created_at"`        // creation time
+	InProgressAt     int64              `json:"in_progress_at"`    // processing start time
+	ExpiresAt        int64              `json:"expires_at"`        // expiration time
+	CompletedAt      int64              `json:"completed_at"`      // completion time
+	FailedAt         int64              `json:"failed_at"`         // failure time
+	CancelledAt      int64              `json:"cancelled_at"`      // cancellation time
+	RequestCounts    BatchRequestCounts `json:"request_counts"`    // request counts
+	Metadata         map[string]string  `json:"metadata"`          // attached metadata
+}
+
+// BatchesData represents a list of batches.
+type BatchesData []*BatchResponse
+
+// BatchListResponse represents the response from
+// the OpenAI Batch API with a list of batches.
+type BatchListResponse struct {
+	Object  string      `json:"object"`   // always "list"
+	Data    BatchesData `json:"data"`     // list of batches
+	FirstID string      `json:"first_id"` // ID of the first batch
+	LastID  string      `json:"last_id"`  // ID of the last batch
+	HasMore bool        `json:"has_more"` // whether there are more batches
+}
+
+// Error returns an error if the request is invalid.
+func (r *BatchCreateRequest) Error() error {
+	if r.InputFileID == "" {
+		return ErrFileRequired
+	}
+
+	if !g.In(r.Endpoint, validBatchEndpoints...) {
+		return ErrInvalidEndpoint
+	}
+
+	if r.CompletionWindow != "" &&
+		r.CompletionWindow != batchCompletionWindow {
+		return ErrInvalidCompletionWindow
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *BatchCreateRequest) Flush() {
+}
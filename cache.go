@@ -0,0 +1,224 @@
+package openai
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Check if InMemoryResponseCache implements ResponseCache interface.
+var _ ResponseCache = (*InMemoryResponseCache)(nil)
+
+// ResponseCache stores the response bodies by the request keys.
+type ResponseCache interface {
+	// Get returns the cached value of the key,
+	// it returns false if there is no value or it's expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores the value of the key for the ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// The cacheEntry is a value of the in-memory cache.
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// InMemoryResponseCache is a ResponseCache that keeps the values in
+// memory. The expired values are evicted by a background goroutine,
+// which is stopped by the Close method.
+type InMemoryResponseCache struct {
+	entries sync.Map
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewInMemoryResponseCache creates an in-memory cache that evicts
+// the expired values every interval, one minute if it's zero.
+func NewInMemoryResponseCache(interval time.Duration) *InMemoryResponseCache {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	c := &InMemoryResponseCache{done: make(chan struct{})}
+	go c.evict(interval)
+
+	return c
+}
+
+// Get implements the ResponseCache interface.
+func (c *InMemoryResponseCache) Get(key string) ([]byte, bool) {
+	v, ok := c.entries.Load(key)
+	if !ok {
+		return nil, false
+	}
+
+	entry := v.(cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.entries.Delete(key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// Set implements the ResponseCache interface.
+func (c *InMemoryResponseCache) Set(key string, value []byte, ttl time.Duration) {
+	c.entries.Store(key, cacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	})
+}
+
+// Close stops the eviction of the expired values.
+func (c *InMemoryResponseCache) Close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// The evict removes the expired values every interval.
+func (c *InMemoryResponseCache) evict(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticker.C:
+			c.entries.Range(func(key, v any) bool {
+				if now.After(v.(cacheEntry).expiresAt) {
+					c.entries.Delete(key)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// NewCacheMiddleware returns a middleware that returns the cached
+// response of the identical JSON request instead of sending it, and
+// caches the successful responses for the ttl. Only the requests of
+// the endpoints without side effects are cached: completions, chat
+// completions, embeddings and moderations. The streamed requests and
+// the requests of the other endpoints are always sent. The responses
+// are cached per API key and organization, so the cache can be shared
+// by the clients of different tenants.
+//
+// Example usage:
+//
+//	cache := openai.NewInMemoryResponseCache(time.Minute)
+//	defer cache.Close()
+//
+//	client.Use(openai.NewCacheMiddleware(cache, time.Hour))
+func NewCacheMiddleware(cache ResponseCache, ttl time.Duration) Middleware {
+	return func(
+		req *http.Request,
+		next http.RoundTripper,
+	) (*http.Response, error) {
+		key, ok := cacheKey(req)
+		if !ok {
+			return next.RoundTrip(req)
+		}
+
+		if body, ok := cache.Get(key); ok {
+			return &http.Response{
+				Status:        "200 OK",
+				StatusCode:    http.StatusOK,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"application/json"}},
+				Body:          io.NopCloser(bytes.NewReader(body)),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil || !isSuccessfulCode(resp.StatusCode) {
+			return resp, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return resp, err
+		}
+
+		cache.Set(key, body, ttl)
+		return resp, nil
+	}
+}
+
+// The cacheableEndpoints are the suffixes of the paths of the endpoints
+// that can be cached, their responses depend on the request only.
+var cacheableEndpoints = []string{"/completions", "/embeddings", "/moderations"}
+
+// The cacheKey returns the SHA-256 hash of the credentials, method, URL
+// and JSON body of the request. The user field is excluded, because it
+// doesn't affect the output, and the keys of the body are sorted, so the
+// key doesn't depend on the order of the fields. It returns false if the
+// request can't be cached: it isn't a POST request to one of the
+// cacheableEndpoints, has no JSON body or asks for a stream.
+func cacheKey(req *http.Request) (string, bool) {
+	if req.Method != http.MethodPost || req.GetBody == nil ||
+		!strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "", false
+	}
+
+	cacheable := false
+	for _, suffix := range cacheableEndpoints {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			cacheable = true
+			break
+		}
+	}
+
+	if !cacheable {
+		return "", false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+
+	fields := map[string]any{}
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		return "", false
+	}
+
+	if stream, _ := fields["stream"].(bool); stream {
+		return "", false
+	}
+
+	delete(fields, "user")
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", false
+	}
+
+	// The credentials are hashed with the request,
+	// so the cache doesn't mix the tenants.
+	sum := sha256.New()
+	for _, header := range []string{
+		"Authorization",
+		"Api-Key",
+		"OpenAI-Organization",
+	} {
+		sum.Write([]byte(header + ": " + req.Header.Get(header) + "\n"))
+	}
+	sum.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	sum.Write(data)
+
+	return hex.EncodeToString(sum.Sum(nil)), true
+}
@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiKeys  [2]string
+		call     func(c *Client) error
+		wantHits int32
+	}{
+		{
+			name:    "chat completion is cached",
+			apiKeys: [2]string{"key-a", "key-a"},
+			call: func(c *Client) error {
+				_, err := c.ChatCompletion(chatRequest(0))
+				return err
+			},
+			wantHits: 1,
+		},
+		{
+			name:    "tenants don't share the cached responses",
+			apiKeys: [2]string{"key-a", "key-b"},
+			call: func(c *Client) error {
+				_, err := c.ChatCompletion(chatRequest(0))
+				return err
+			},
+			wantHits: 2,
+		},
+		{
+			name:    "message creation isn't cached",
+			apiKeys: [2]string{"key-a", "key-a"},
+			call: func(c *Client) error {
+				_, err := c.MessageCreate("thread_1", &MessageCreateRequest{
+					Role:    "user",
+					Content: "Hello",
+				})
+				return err
+			},
+			wantHits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"msg_1","choices":[]}`))
+			}
+
+			cache := NewInMemoryResponseCache(time.Minute)
+			defer cache.Close()
+
+			c := newTestClient(
+				t,
+				handler,
+				WithMiddleware(NewCacheMiddleware(cache, time.Hour)),
+			)
+
+			for _, key := range tt.apiKeys {
+				c.Configure(Config{APIKey: key})
+				if err := tt.call(c); err != nil {
+					t.Fatalf("error = %v", err)
+				}
+			}
+
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package openai
 
 import (
+	"encoding/json"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/goloop/g"
 )
@@ -9,7 +12,9 @@ import (
 // Check if ChatCompletionRequest implements Requester interface.
 var _ Requester = (*ChatCompletionRequest)(nil)
 
-var availableRoleList = []string{"system", "user", "assistant"}
+var availableRoleList = []string{
+	"system", "user", "assistant", "tool", "function",
+}
 
 const DefaultRole = "user"
 
@@ -18,31 +23,90 @@ type ChatCompletionRequest struct {
 	Messages         []ChatCompletionMessage `json:"messages"`
 	Model            string                  `json:"model"`
 	MaxTokens        int                     `json:"max_tokens,omitempty"`
+	N                int                     `json:"n,omitempty"`
 	Temperature      float64                 `json:"temperature,omitempty"`
 	TopP             float64                 `json:"top_p,omitempty"`
 	FrequencyPenalty float64                 `json:"frequency_penalty,omitempty"`
 	PresencePenalty  float64                 `json:"presence_penalty,omitempty"`
 	LogitBias        map[string]float64      `json:"logit_bias,omitempty"`
+	Tools            []Tool                  `json:"tools,omitempty"`
+	ToolChoice       interface{}             `json:"tool_choice,omitempty"`
+	ResponseFormat   *ResponseFormat         `json:"response_format,omitempty"`
+	Seed             *int                    `json:"seed,omitempty"`
+	LogProbs         bool                    `json:"logprobs,omitempty"`
+	TopLogProbs      int                     `json:"top_logprobs,omitempty"`
+	Stop             interface{}             `json:"stop,omitempty"`
+	Timeout          time.Duration           `json:"-"`
 }
 
 type ChatCompletionResponse struct {
-	ID      string                  `json:"id"`
-	Object  string                  `json:"object"`
-	Created int64                   `json:"created"`
-	Choices []ChatCompletionChoices `json:"choices"`
-	Usage   ChatCompletionUsage     `json:"usage"`
+	ID                string                  `json:"id"`
+	Object            string                  `json:"object"`
+	Created           int64                   `json:"created"`
+	Model             string                  `json:"model,omitempty"`
+	SystemFingerprint string                  `json:"system_fingerprint,omitempty"`
+	Choices           []ChatCompletionChoices `json:"choices"`
+	Usage             ChatCompletionUsage     `json:"usage"`
 }
 
 type ChatCompletionChoices struct {
 	Index        int                   `json:"index"`
 	Message      ChatCompletionMessage `json:"message"`
 	FinishReason string                `json:"finish_reason"`
+	ToolCalls    []ToolCall            `json:"tool_calls,omitempty"`
+	LogProbs     *LogProbContent       `json:"logprobs,omitempty"`
+}
+
+// LogProbContent is the log probability information of the choice.
+type LogProbContent struct {
+	// A list of message content tokens with
+	// the log probability information.
+	Content []TokenLogProb `json:"content"`
+}
+
+// TokenLogProb is the log probability information of the token.
+type TokenLogProb struct {
+	Token       string       `json:"token"`                  // the token
+	LogProb     float64      `json:"logprob"`                // log probability
+	Bytes       []int        `json:"bytes,omitempty"`        // UTF-8 bytes
+	TopLogProbs []TopLogProb `json:"top_logprobs,omitempty"` // most likely tokens
+}
+
+// TopLogProb is one of the most likely tokens at the token position.
+type TopLogProb struct {
+	Token   string  `json:"token"`           // the token
+	LogProb float64 `json:"logprob"`         // log probability
+	Bytes   []int   `json:"bytes,omitempty"` // UTF-8 bytes
 }
 
+// ChatCompletionMessage is a message of the chat conversation.
+// The Content is either a plain string or a []ContentPart slice
+// for multi-modal messages (text with images).
 type ChatCompletionMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-	Name    string `json:"name,omitempty"`
+	Role       string      `json:"role"`
+	Content    interface{} `json:"content"`
+	Name       string      `json:"name,omitempty"`
+	ToolCalls  []ToolCall  `json:"tool_calls,omitempty"`
+	ToolCallID string      `json:"tool_call_id,omitempty"`
+}
+
+// ContentPart is a part of the multi-modal message content.
+// The Type defines which of the other fields is set:
+// "text" or "image_url".
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL is the image of the multi-modal message content.
+type ImageURL struct {
+	// The URL of the image or the base64 encoded image data
+	// as a data URL, like "data:image/jpeg;base64,...".
+	URL string `json:"url"`
+
+	// The detail level of the image: "auto", "low" or "high".
+	Detail string `json:"detail,omitempty"`
 }
 
 type ChatCompletionUsage struct {
@@ -51,6 +115,12 @@ type ChatCompletionUsage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
+// ChatCompletionBatchOptions are the options of the ChatCompletionBatch.
+type ChatCompletionBatchOptions struct {
+	// FailFast cancels the rest of the batch when any request fails.
+	FailFast bool
+}
+
 // Error returns an error if the request is invalid.
 func (r *ChatCompletionRequest) Error() error {
 	if r.Model == "" {
@@ -61,16 +131,43 @@ func (r *ChatCompletionRequest) Error() error {
 		return ErrMessageRequired
 	}
 
-	for _, message := range r.Messages {
-		if !g.In(message.Role, availableRoleList...) {
-			return ErrInvalidRole
+	for i := range r.Messages {
+		if err := r.Messages[i].Validate(); err != nil {
+			return err
 		}
+	}
 
-		if message.Content == "" {
-			return ErrPromptRequired
+	err := validateSampling(
+		r.Temperature,
+		r.TopP,
+		r.PresencePenalty,
+		r.FrequencyPenalty,
+	)
+	if err != nil {
+		return err
+	}
+
+	if r.N < 0 {
+		return ErrInvalidN
+	}
+
+	if err := validateStop(r.Stop); err != nil {
+		return err
+	}
+
+	if r.ResponseFormat != nil {
+		if err := r.ResponseFormat.Error(); err != nil {
+			return err
 		}
 	}
 
+	// The top log probabilities can be requested
+	// only with the log probabilities enabled.
+	if r.TopLogProbs < 0 || r.TopLogProbs > 20 ||
+		(r.TopLogProbs > 0 && !r.LogProbs) {
+		return ErrInvalidTopLogProbs
+	}
+
 	return nil
 }
 
@@ -78,6 +175,64 @@ func (r *ChatCompletionRequest) Error() error {
 func (r *ChatCompletionRequest) Flush() {
 }
 
+// The timeout returns the timeout of the request.
+func (r *ChatCompletionRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// UnmarshalJSON decodes the choice and copies the tool calls
+// of the message to the ToolCalls field of the choice.
+func (c *ChatCompletionChoices) UnmarshalJSON(data []byte) error {
+	// The alias type has no methods, it prevents recursion.
+	type alias ChatCompletionChoices
+	tmp := alias{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	*c = ChatCompletionChoices(tmp)
+	if len(c.ToolCalls) == 0 {
+		c.ToolCalls = c.Message.ToolCalls
+	}
+
+	return nil
+}
+
+// WithTools appends the tools to the list of tools
+// the model may call and returns the request.
+func (r *ChatCompletionRequest) WithTools(tools ...Tool) *ChatCompletionRequest {
+	r.Tools = append(r.Tools, tools...)
+	return r
+}
+
+// WithToolChoice sets which (if any) tool is called
+// by the model and returns the request.
+func (r *ChatCompletionRequest) WithToolChoice(
+	choice interface{},
+) *ChatCompletionRequest {
+	r.ToolChoice = choice
+	return r
+}
+
+// RequireToolCall forces the model to call the tool
+// with the given name and returns the request.
+func (r *ChatCompletionRequest) RequireToolCall(
+	toolName string,
+) *ChatCompletionRequest {
+	r.ToolChoice = map[string]interface{}{
+		"type":     ToolTypeFunction,
+		"function": map[string]string{"name": toolName},
+	}
+	return r
+}
+
+// AllowToolCalls lets the model pick between generating
+// a message or calling tools and returns the request.
+func (r *ChatCompletionRequest) AllowToolCalls() *ChatCompletionRequest {
+	r.ToolChoice = "auto"
+	return r
+}
+
 // Text returns the text of the first choice.
 func (r *ChatCompletionResponse) Text() string {
 	var sb strings.Builder
@@ -90,8 +245,229 @@ func (r *ChatCompletionResponse) Text() string {
 		if i > 0 {
 			sb.WriteString("\n")
 		}
-		sb.WriteString(strings.TrimSpace(choice.Message.Content))
+		sb.WriteString(strings.TrimSpace(choice.Message.ContentText()))
 	}
 
 	return sb.String()
 }
+
+// FirstText returns the content of the first choice,
+// or an empty string if there are no choices.
+func (r *ChatCompletionResponse) FirstText() string {
+	if len(r.Choices) == 0 {
+		return ""
+	}
+
+	return r.Choices[0].Message.ContentText()
+}
+
+// AllTexts returns the content of every choice,
+// e.g. of the response to the request with N > 1.
+func (r *ChatCompletionResponse) AllTexts() []string {
+	texts := make([]string, len(r.Choices))
+	for i, choice := range r.Choices {
+		texts[i] = choice.Message.ContentText()
+	}
+
+	return texts
+}
+
+// AllFinishReasons returns the finish reason of every choice.
+func (r *ChatCompletionResponse) AllFinishReasons() []string {
+	reasons := make([]string, len(r.Choices))
+	for i, choice := range r.Choices {
+		reasons[i] = choice.FinishReason
+	}
+
+	return reasons
+}
+
+// BestChoice returns the first choice that finished with "stop", which
+// is preferred over the choices cut by "length", or the first choice if
+// none of them finished cleanly. It returns nil if there are no choices.
+func (r *ChatCompletionResponse) BestChoice() *ChatCompletionChoices {
+	if len(r.Choices) == 0 {
+		return nil
+	}
+
+	for i := range r.Choices {
+		if r.Choices[i].FinishReason == "stop" {
+			return &r.Choices[i]
+		}
+	}
+
+	return &r.Choices[0]
+}
+
+// Process applies the processors in order to the text of the first choice
+// and returns the transformed result, or the first error returned by
+// a processor.
+//
+// Example usage:
+//
+//	data, err := resp.Process(
+//	    openai.RemoveMarkdownCodeFences,
+//	    openai.ExtractJSON,
+//	)
+func (r *ChatCompletionResponse) Process(
+	processors ...ResponseProcessor,
+) (string, error) {
+	text := r.FirstText()
+	for _, process := range processors {
+		var err error
+		if text, err = process(text); err != nil {
+			return "", err
+		}
+	}
+
+	return text, nil
+}
+
+// Truncate returns the first maxChars characters of the message content
+// followed by "..." if the content was truncated, or the full content if
+// it fits. The content is cut on a rune boundary, so multi-byte characters
+// are never broken.
+func (m *ChatCompletionMessage) Truncate(maxChars int) string {
+	if maxChars < 0 {
+		maxChars = 0
+	}
+
+	content := m.ContentText()
+	if utf8.RuneCountInString(content) <= maxChars {
+		return content
+	}
+
+	runes := []rune(content)
+	return string(runes[:maxChars]) + "..."
+}
+
+// Preview returns a single-line preview of the message content suitable
+// for logging or UI display. Leading and trailing whitespace is stripped,
+// internal newlines are replaced with spaces, and the result is truncated
+// to maxChars characters like in the Truncate method.
+func (m *ChatCompletionMessage) Preview(maxChars int) string {
+	content := strings.TrimSpace(m.ContentText())
+	content = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").
+		Replace(content)
+
+	tmp := ChatCompletionMessage{Content: content}
+	return tmp.Truncate(maxChars)
+}
+
+// NewTextPart returns a text part of the multi-modal message content.
+func NewTextPart(text string) ContentPart {
+	return ContentPart{Type: "text", Text: text}
+}
+
+// NewImageURLPart returns an image part of the multi-modal message
+// content. The detail is the detail level of the image: "auto", "low"
+// or "high", it can be empty to use the default level.
+func NewImageURLPart(url, detail string) ContentPart {
+	return ContentPart{
+		Type:     "image_url",
+		ImageURL: &ImageURL{URL: url, Detail: detail},
+	}
+}
+
+// ContentText returns the text of the message content. For the
+// multi-modal content, the text parts are joined with a newline.
+func (m *ChatCompletionMessage) ContentText() string {
+	switch content := m.Content.(type) {
+	case string:
+		return content
+	case []ContentPart:
+		texts := make([]string, 0, len(content))
+		for _, part := range content {
+			if part.Type == "text" {
+				texts = append(texts, part.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+
+	return ""
+}
+
+// Validate returns an error if the message is invalid for its role.
+// The tool messages require the ID of the tool call they answer, the
+// legacy function messages require the name of the function, and the
+// assistant messages with tool calls may have no content.
+func (m *ChatCompletionMessage) Validate() error {
+	if !g.In(m.Role, availableRoleList...) {
+		return ErrInvalidRole
+	}
+
+	switch m.Role {
+	case "tool":
+		if m.ToolCallID == "" {
+			return ErrToolCallIDRequired
+		}
+	case "function":
+		if m.Name == "" {
+			return ErrNameRequired
+		}
+	case "assistant":
+		if len(m.ToolCalls) != 0 {
+			return nil
+		}
+	}
+
+	if !m.hasContent() {
+		return ErrPromptRequired
+	}
+
+	return nil
+}
+
+// The hasContent returns true if the message content is
+// a non-empty string or contains at least one non-empty part.
+func (m *ChatCompletionMessage) hasContent() bool {
+	switch content := m.Content.(type) {
+	case string:
+		return content != ""
+	case []ContentPart:
+		for _, part := range content {
+			if part.Text != "" ||
+				(part.ImageURL != nil && part.ImageURL.URL != "") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// UnmarshalJSON decodes the message, the content is decoded
+// as a string or as a []ContentPart slice.
+func (m *ChatCompletionMessage) UnmarshalJSON(data []byte) error {
+	// The alias type has no methods, it prevents recursion.
+	type alias ChatCompletionMessage
+	tmp := struct {
+		*alias
+		Content json.RawMessage `json:"content"`
+	}{alias: (*alias)(m)}
+
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	m.Content = nil
+	if len(tmp.Content) == 0 || string(tmp.Content) == "null" {
+		return nil
+	}
+
+	// The content is a string in the most cases.
+	var text string
+	if err := json.Unmarshal(tmp.Content, &text); err == nil {
+		m.Content = text
+		return nil
+	}
+
+	var parts []ContentPart
+	if err := json.Unmarshal(tmp.Content, &parts); err != nil {
+		return err
+	}
+
+	m.Content = parts
+	return nil
+}
@@ -0,0 +1,113 @@
+package openai
+
+// ChatCompletionRequestBuilder builds the chat completion requests
+// of multi-turn conversations. The builder can be reused: the Build
+// method returns a new request each time and keeps the messages.
+//
+// Example usage:
+//
+//	r, err := openai.NewChatCompletionRequestBuilder("gpt-4o").
+//	    System("You are a helpful assistant.").
+//	    User("Hello!").
+//	    WithMaxTokens(256).
+//	    Build()
+type ChatCompletionRequestBuilder struct {
+	request ChatCompletionRequest
+}
+
+// NewChatCompletionRequestBuilder creates a new builder
+// of the requests to the model.
+func NewChatCompletionRequestBuilder(
+	model string,
+) *ChatCompletionRequestBuilder {
+	return &ChatCompletionRequestBuilder{
+		request: ChatCompletionRequest{Model: model},
+	}
+}
+
+// System appends the system message.
+func (b *ChatCompletionRequestBuilder) System(
+	content string,
+) *ChatCompletionRequestBuilder {
+	return b.add(ChatCompletionMessage{Role: "system", Content: content})
+}
+
+// User appends the user message.
+func (b *ChatCompletionRequestBuilder) User(
+	content string,
+) *ChatCompletionRequestBuilder {
+	return b.add(ChatCompletionMessage{Role: "user", Content: content})
+}
+
+// Assistant appends the assistant message.
+func (b *ChatCompletionRequestBuilder) Assistant(
+	content string,
+) *ChatCompletionRequestBuilder {
+	return b.add(ChatCompletionMessage{Role: "assistant", Content: content})
+}
+
+// ToolResult appends the result of the tool call.
+func (b *ChatCompletionRequestBuilder) ToolResult(
+	toolCallID, content string,
+) *ChatCompletionRequestBuilder {
+	return b.add(ChatCompletionMessage{
+		Role:       "tool",
+		Content:    content,
+		ToolCallID: toolCallID,
+	})
+}
+
+// WithMaxTokens sets the maximum number of tokens to generate.
+func (b *ChatCompletionRequestBuilder) WithMaxTokens(
+	n int,
+) *ChatCompletionRequestBuilder {
+	b.request.MaxTokens = n
+	return b
+}
+
+// WithTemperature sets the sampling temperature.
+func (b *ChatCompletionRequestBuilder) WithTemperature(
+	t float64,
+) *ChatCompletionRequestBuilder {
+	b.request.Temperature = t
+	return b
+}
+
+// WithTools sets the tools the model may call.
+func (b *ChatCompletionRequestBuilder) WithTools(
+	tools ...Tool,
+) *ChatCompletionRequestBuilder {
+	b.request.WithTools(tools...)
+	return b
+}
+
+// Build returns a new request with the accumulated messages and
+// parameters, or an error if the request is invalid.
+func (b *ChatCompletionRequestBuilder) Build() (*ChatCompletionRequest, error) {
+	r := b.request
+	r.Messages = append([]ChatCompletionMessage{}, b.request.Messages...)
+	if b.request.Tools != nil {
+		r.Tools = append([]Tool{}, b.request.Tools...)
+	}
+
+	if err := r.Error(); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Reset removes the accumulated messages and parameters,
+// only the model of the requests is kept.
+func (b *ChatCompletionRequestBuilder) Reset() *ChatCompletionRequestBuilder {
+	b.request = ChatCompletionRequest{Model: b.request.Model}
+	return b
+}
+
+// The add appends the message to the request.
+func (b *ChatCompletionRequestBuilder) add(
+	m ChatCompletionMessage,
+) *ChatCompletionRequestBuilder {
+	b.request.Messages = append(b.request.Messages, m)
+	return b
+}
@@ -0,0 +1,348 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestChatCompletionToolCalls tests the round trip of the tool calls:
+// the model calls the tool and gets the result back in the next request.
+func TestChatCompletionToolCalls(t *testing.T) {
+	responses := []string{
+		`{"choices":[{"message":{"role":"assistant","content":null,` +
+			`"tool_calls":[{"id":"call_abc","type":"function","function":` +
+			`{"name":"get_weather","arguments":"{\"city\":\"Kyiv\"}"}}]},` +
+			`"finish_reason":"tool_calls"}]}`,
+		`{"choices":[{"message":{"role":"assistant",` +
+			`"content":"It's sunny in Kyiv."},"finish_reason":"stop"}]}`,
+	}
+
+	var requests []ChatCompletionRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+
+		w.Write([]byte(responses[len(requests)]))
+		requests = append(requests, req)
+	})
+
+	req := &ChatCompletionRequest{
+		Model: "gpt-4o",
+		Messages: []ChatCompletionMessage{
+			{Role: "user", Content: "What's the weather like in Kyiv?"},
+		},
+	}
+
+	req.WithTools(Tool{
+		Type: ToolTypeFunction,
+		Function: FunctionDefinition{
+			Name:       "get_weather",
+			Parameters: json.RawMessage(`{"type":"object"}`),
+		},
+	}).AllowToolCalls()
+
+	resp, err := c.ChatCompletion(req)
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+
+	calls := resp.Choices[0].ToolCalls
+	if len(calls) != 1 || calls[0].ID != "call_abc" {
+		t.Fatalf("ToolCalls = %+v, want the call_abc call", calls)
+	}
+
+	var args struct {
+		City string `json:"city"`
+	}
+
+	if err := calls[0].Function.ParseArguments(&args); err != nil {
+		t.Fatalf("ParseArguments() error = %v", err)
+	}
+
+	if args.City != "Kyiv" {
+		t.Errorf("city = %q, want Kyiv", args.City)
+	}
+
+	req.Messages = append(req.Messages,
+		resp.Choices[0].Message,
+		ChatCompletionMessage{
+			Role:       "tool",
+			ToolCallID: calls[0].ID,
+			Content:    "sunny",
+		},
+	)
+
+	resp, err = c.ChatCompletion(req)
+	if err != nil {
+		t.Fatalf("ChatCompletion() with the tool result error = %v", err)
+	}
+
+	if got := resp.FirstText(); got != "It's sunny in Kyiv." {
+		t.Errorf("FirstText() = %q", got)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+
+	sent := requests[1].Messages
+	if len(sent) != 3 ||
+		len(sent[1].ToolCalls) != 1 ||
+		sent[2].ToolCallID != "call_abc" {
+		t.Errorf("messages of the second request = %+v", sent)
+	}
+
+	if requests[0].ToolChoice != "auto" || len(requests[0].Tools) != 1 {
+		t.Errorf("tools of the first request = %+v, %v",
+			requests[0].Tools, requests[0].ToolChoice)
+	}
+}
+
+func TestChatCompletionMessageContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content interface{}
+		json    string
+		err     error
+	}{
+		{
+			name:    "string",
+			content: "Hello",
+			json:    `{"role":"user","content":"Hello"}`,
+		},
+		{
+			name: "parts",
+			content: []ContentPart{
+				NewTextPart("What's in this image?"),
+				NewImageURLPart("https://example.com/cat.png", "low"),
+			},
+			json: `{"role":"user","content":[` +
+				`{"type":"text","text":"What's in this image?"},` +
+				`{"type":"image_url","image_url":` +
+				`{"url":"https://example.com/cat.png","detail":"low"}}]}`,
+		},
+		{
+			name: "image only",
+			content: []ContentPart{
+				NewImageURLPart("https://example.com/cat.png", ""),
+			},
+			json: `{"role":"user","content":[{"type":"image_url",` +
+				`"image_url":{"url":"https://example.com/cat.png"}}]}`,
+		},
+		{
+			name:    "empty parts",
+			content: []ContentPart{NewTextPart("")},
+			json:    `{"role":"user","content":[{"type":"text"}]}`,
+			err:     ErrPromptRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ChatCompletionMessage{Role: "user", Content: tt.content}
+
+			data, err := json.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			if string(data) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", data, tt.json)
+			}
+
+			var got ChatCompletionMessage
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, m) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, m)
+			}
+
+			r := &ChatCompletionRequest{
+				Model:    "gpt-4o",
+				Messages: []ChatCompletionMessage{m},
+			}
+			if err := r.Error(); !errors.Is(err, tt.err) {
+				t.Errorf("Error() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestChatCompletionLogProbs(t *testing.T) {
+	const data = `{"id":"chatcmpl-abc","object":"chat.completion",` +
+		`"created":1700000000,"model":"gpt-4o","system_fingerprint":"fp_abc",` +
+		`"choices":[{"index":0,"message":{"role":"assistant","content":"Hi"},` +
+		`"finish_reason":"stop","logprobs":{"content":[{"token":"Hi",` +
+		`"logprob":-0.5,"bytes":[72,105],"top_logprobs":` +
+		`[{"token":"Hi","logprob":-0.5},{"token":"Hello","logprob":-1.5}]}]}}],` +
+		`"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`
+
+	var resp ChatCompletionResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if resp.SystemFingerprint != "fp_abc" {
+		t.Errorf("SystemFingerprint = %q, want %q",
+			resp.SystemFingerprint, "fp_abc")
+	}
+
+	want := &LogProbContent{Content: []TokenLogProb{{
+		Token:   "Hi",
+		LogProb: -0.5,
+		Bytes:   []int{72, 105},
+		TopLogProbs: []TopLogProb{
+			{Token: "Hi", LogProb: -0.5},
+			{Token: "Hello", LogProb: -1.5},
+		},
+	}}}
+	if got := resp.Choices[0].LogProbs; !reflect.DeepEqual(got, want) {
+		t.Errorf("LogProbs = %+v, want %+v", got, want)
+	}
+
+	// The encoded response is decoded to the same value.
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got ChatCompletionResponse
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, resp) {
+		t.Errorf("round trip = %+v, want %+v", got, resp)
+	}
+}
+
+func TestChatCompletionRequestOmitEmpty(t *testing.T) {
+	seed := 0
+	tests := []struct {
+		name string
+		r    ChatCompletionRequest
+		want string
+	}{
+		{
+			name: "unset",
+			r:    ChatCompletionRequest{Model: "gpt-4o"},
+			want: `{"messages":null,"model":"gpt-4o"}`,
+		},
+		{
+			name: "set",
+			r: ChatCompletionRequest{
+				Model:       "gpt-4o",
+				Seed:        &seed,
+				LogProbs:    true,
+				TopLogProbs: 2,
+			},
+			want: `{"messages":null,"model":"gpt-4o","seed":0,` +
+				`"logprobs":true,"top_logprobs":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.r)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			if string(data) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletionMessageValidate(t *testing.T) {
+	toolCalls := []ToolCall{{
+		ID:       "call_abc",
+		Type:     ToolTypeFunction,
+		Function: ToolCallFunction{Name: "get_weather", Arguments: "{}"},
+	}}
+
+	tests := []struct {
+		name string
+		m    ChatCompletionMessage
+		err  error
+	}{
+		{
+			name: "system",
+			m:    ChatCompletionMessage{Role: "system", Content: "Be brief."},
+		},
+		{
+			name: "user",
+			m:    ChatCompletionMessage{Role: "user", Content: "Hello"},
+		},
+		{
+			name: "user without content",
+			m:    ChatCompletionMessage{Role: "user"},
+			err:  ErrPromptRequired,
+		},
+		{
+			name: "assistant",
+			m:    ChatCompletionMessage{Role: "assistant", Content: "Hi"},
+		},
+		{
+			name: "assistant with tool calls",
+			m:    ChatCompletionMessage{Role: "assistant", ToolCalls: toolCalls},
+		},
+		{
+			name: "assistant without content",
+			m:    ChatCompletionMessage{Role: "assistant"},
+			err:  ErrPromptRequired,
+		},
+		{
+			name: "tool",
+			m: ChatCompletionMessage{
+				Role:       "tool",
+				Content:    "Sunny",
+				ToolCallID: "call_abc",
+			},
+		},
+		{
+			name: "tool without tool call ID",
+			m:    ChatCompletionMessage{Role: "tool", Content: "Sunny"},
+			err:  ErrToolCallIDRequired,
+		},
+		{
+			name: "tool without content",
+			m:    ChatCompletionMessage{Role: "tool", ToolCallID: "call_abc"},
+			err:  ErrPromptRequired,
+		},
+		{
+			name: "function",
+			m: ChatCompletionMessage{
+				Role:    "function",
+				Content: "Sunny",
+				Name:    "get_weather",
+			},
+		},
+		{
+			name: "function without name",
+			m:    ChatCompletionMessage{Role: "function", Content: "Sunny"},
+			err:  ErrNameRequired,
+		},
+		{
+			name: "unknown role",
+			m:    ChatCompletionMessage{Role: "robot", Content: "Hello"},
+			err:  ErrInvalidRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Validate() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// circuitMaxBackoff is the upper bound of the factor of the open
+// duration, it's doubled on each failed probe.
+const circuitMaxBackoff = 64
+
+// CircuitState is the state of the CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed passes all requests.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects all requests with ErrCircuitOpen.
+	CircuitOpen
+
+	// CircuitHalfOpen passes a single probe request.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return "closed"
+}
+
+// CircuitBreaker stops sending requests when the API is degraded.
+// It opens after the threshold of consecutive failures, i.e. transport
+// errors or 5xx responses, and rejects the requests with ErrCircuitOpen
+// without a network call while it's open. After the open duration it
+// lets a single probe request through: a success closes the breaker,
+// a failure opens it again for twice as long. The results of the other
+// requests finished meanwhile are ignored, and the requests cancelled
+// by the caller aren't counted at all.
+//
+// Example usage:
+//
+//	breaker := openai.NewCircuitBreaker(5, 30*time.Second)
+//	client.Use(breaker.Middleware())
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	threshold    int           // consecutive failures to open
+	openDuration time.Duration // initial duration of the open state
+
+	state    CircuitState  // current state
+	failures int           // consecutive failures in the closed state
+	openedAt time.Time     // time the breaker was opened
+	openFor  time.Duration // duration of the current open state
+	probing  bool          // the probe request is in flight
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after the
+// threshold of consecutive failures for the openDuration.
+func NewCircuitBreaker(threshold int, openDuration time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		openFor:      openDuration,
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.openFor {
+		return CircuitHalfOpen
+	}
+
+	return b.state
+}
+
+// Middleware returns the middleware to register on the client.
+func (b *CircuitBreaker) Middleware() Middleware {
+	return b.roundTrip
+}
+
+// The roundTrip sends the request if the breaker allows it
+// and records its result.
+func (b *CircuitBreaker) roundTrip(
+	req *http.Request,
+	next http.RoundTripper,
+) (*http.Response, error) {
+	probe, ok := b.allow()
+	if !ok {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := next.RoundTrip(req)
+
+	// The cancelled request says nothing about the API,
+	// so the probe is just released to be sent again.
+	if errors.Is(err, context.Canceled) {
+		b.release(probe)
+		return resp, err
+	}
+
+	b.record(
+		probe,
+		err != nil || resp.StatusCode >= http.StatusInternalServerError,
+	)
+
+	return resp, err
+}
+
+// The allow returns true if the request can be sent,
+// and the probe is true if the request is the probe one.
+func (b *CircuitBreaker) allow() (probe, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.openFor {
+			return false, false
+		}
+
+		b.state = CircuitHalfOpen
+		fallthrough
+	case CircuitHalfOpen:
+		// Only one probe request is in flight at a time.
+		if b.probing {
+			return false, false
+		}
+
+		b.probing = true
+		return true, true
+	}
+
+	return false, true
+}
+
+// The release lets the next request be the probe one.
+func (b *CircuitBreaker) release(probe bool) {
+	if !probe {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// The record updates the state by the result of the request.
+func (b *CircuitBreaker) record(probe, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+		if failed {
+			b.openFor = min(b.openFor*2, b.openDuration*circuitMaxBackoff)
+			b.open()
+			return
+		}
+
+		b.state, b.failures, b.openFor = CircuitClosed, 0, b.openDuration
+		return
+	}
+
+	// The requests sent before the breaker opened
+	// don't change the state until it's closed.
+	if b.state != CircuitClosed {
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open()
+	}
+}
+
+// The open switches the breaker to the open state.
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+}
@@ -0,0 +1,126 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// The circuitRequest sends the request through the breaker,
+// the next round tripper responds with the status or the err.
+func circuitRequest(b *CircuitBreaker, status int, err error) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com", nil)
+	_, err = b.roundTrip(req, roundTripFunc(func(*http.Request) (*http.Response, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+	}))
+
+	return err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	serverError := func(b *CircuitBreaker) error {
+		return circuitRequest(b, http.StatusInternalServerError, nil)
+	}
+	cancelled := func(b *CircuitBreaker) error {
+		return circuitRequest(b, 0, context.Canceled)
+	}
+	ok := func(b *CircuitBreaker) error {
+		return circuitRequest(b, http.StatusOK, nil)
+	}
+	wait := func(b *CircuitBreaker) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	tests := []struct {
+		name  string
+		steps []func(*CircuitBreaker) error
+		want  CircuitState
+	}{
+		{
+			name:  "opens after the threshold",
+			steps: []func(*CircuitBreaker) error{serverError, serverError},
+			want:  CircuitOpen,
+		},
+		{
+			name:  "cancelled requests aren't failures",
+			steps: []func(*CircuitBreaker) error{cancelled, cancelled, cancelled},
+			want:  CircuitClosed,
+		},
+		{
+			name: "successful probe closes",
+			steps: []func(*CircuitBreaker) error{
+				serverError, serverError, wait, ok,
+			},
+			want: CircuitClosed,
+		},
+		{
+			name: "cancelled probe is sent again",
+			steps: []func(*CircuitBreaker) error{
+				serverError, serverError, wait, cancelled, ok,
+			},
+			want: CircuitClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker(2, 10*time.Millisecond)
+			for i, step := range tt.steps {
+				err := step(b)
+				if err != nil && !errors.Is(err, context.Canceled) {
+					t.Fatalf("step %d error = %v", i, err)
+				}
+			}
+
+			if got := b.State(); got != tt.want {
+				t.Errorf("State() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCircuitBreakerLateResult tests that the result of the request
+// sent before the breaker opened doesn't replace the probe result.
+func TestCircuitBreakerLateResult(t *testing.T) {
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+
+	// The slow request is sent in the closed state.
+	release, done := make(chan struct{}), make(chan error)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com", nil)
+		_, err := b.roundTrip(req, roundTripFunc(func(*http.Request) (*http.Response, error) {
+			<-release
+			return nil, errors.New("connection reset")
+		}))
+		done <- err
+	}()
+
+	// Wait for the slow request to pass the breaker.
+	time.Sleep(10 * time.Millisecond)
+	circuitRequest(b, http.StatusInternalServerError, nil)
+	if got := b.State(); got != CircuitOpen {
+		t.Fatalf("State() = %s, want open", got)
+	}
+
+	// The slow request fails while the probe is in flight.
+	time.Sleep(20 * time.Millisecond)
+	probe, _ := b.allow()
+	if !probe {
+		t.Fatal("allow() didn't pass the probe")
+	}
+
+	close(release)
+	<-done
+
+	b.record(probe, false)
+	if got := b.State(); got != CircuitClosed {
+		t.Errorf("State() = %s, want closed", got)
+	}
+}
@@ -0,0 +1,545 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goloop/g"
+)
+
+// The newTestClient creates a client of the test server with the handler.
+func newTestClient(
+	t *testing.T,
+	handler http.HandlerFunc,
+	opts ...Option,
+) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{
+		WithAPIKey("test-key"),
+		WithAPIBaseURL(srv.URL + "/v1"),
+	}, opts...)
+
+	c, err := NewClient(opts...)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return c
+}
+
+// The slowHandler responds with the body after the delay,
+// or returns earlier if the request is cancelled.
+func slowHandler(delay time.Duration, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(delay):
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+// The chatRequest returns a valid chat completion request.
+func chatRequest(timeout time.Duration) *ChatCompletionRequest {
+	return &ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []ChatCompletionMessage{{Role: "user", Content: "Hello"}},
+		Timeout:  timeout,
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	const body = `{"choices":[{"message":{"role":"assistant","content":"Hi"}}]}`
+
+	tests := []struct {
+		name          string
+		serverDelay   time.Duration
+		clientTimeout time.Duration
+		timeout       time.Duration
+		batch         bool
+		wantErr       error
+	}{
+		{
+			name:        "request timeout expires",
+			serverDelay: 200 * time.Millisecond,
+			timeout:     time.Millisecond,
+			wantErr:     ErrRequestTimedOut,
+		},
+		{
+			name:          "request timeout longer than client timeout",
+			serverDelay:   300 * time.Millisecond,
+			clientTimeout: 100 * time.Millisecond,
+			timeout:       2 * time.Second,
+		},
+		{
+			name:          "client timeout without request timeout",
+			serverDelay:   300 * time.Millisecond,
+			clientTimeout: 100 * time.Millisecond,
+			wantErr:       ErrRequestTimedOut,
+		},
+		{
+			name:        "batch keeps request timeout",
+			serverDelay: 500 * time.Millisecond,
+			timeout:     50 * time.Millisecond,
+			batch:       true,
+			wantErr:     ErrRequestTimedOut,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.clientTimeout > 0 {
+				opts = append(opts, WithTimeout(tt.clientTimeout))
+			}
+
+			c := newTestClient(t, slowHandler(tt.serverDelay, body), opts...)
+
+			var err error
+			if tt.batch {
+				_, errs := c.ChatCompletionBatch(
+					context.Background(),
+					[]*ChatCompletionRequest{chatRequest(tt.timeout)},
+					nil,
+				)
+				err = errs[0]
+			} else {
+				_, err = c.ChatCompletion(chatRequest(tt.timeout))
+			}
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error = %v, want nil", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultModel(t *testing.T) {
+	const body = `{"choices":[{"text":"Hi","message":{"content":"Hi"}}]}`
+
+	// Each call returns the model of the caller's request after
+	// the call, the default model must not be written into it.
+	tests := []struct {
+		name string
+		call func(c *Client) (string, error)
+	}{
+		{
+			name: "Completion",
+			call: func(c *Client) (string, error) {
+				r := &CompletionRequest{Prompt: "Hello"}
+				_, err := c.Completion(r)
+				return r.Model, err
+			},
+		},
+		{
+			name: "CompletionStream",
+			call: func(c *Client) (string, error) {
+				r := &CompletionRequest{Prompt: "Hello"}
+				deltas, errs := c.CompletionStream(r)
+				for range deltas {
+				}
+				return r.Model, <-errs
+			},
+		},
+		{
+			name: "CompletionMulti",
+			call: func(c *Client) (string, error) {
+				_, errs := c.CompletionMulti("", []string{"Hello"})
+				return "", errs[0]
+			},
+		},
+		{
+			name: "ChatCompletion",
+			call: func(c *Client) (string, error) {
+				r := chatRequest(0)
+				r.Model = ""
+				_, err := c.ChatCompletion(r)
+				return r.Model, err
+			},
+		},
+		{
+			name: "ChatCompletionBatch",
+			call: func(c *Client) (string, error) {
+				r := chatRequest(0)
+				r.Model = ""
+				_, errs := c.ChatCompletionBatch(
+					nil,
+					[]*ChatCompletionRequest{r, r},
+					nil,
+				)
+				return r.Model, errors.Join(errs...)
+			},
+		},
+		{
+			name: "Edit",
+			call: func(c *Client) (string, error) {
+				r := &EditRequest{Instruction: "Fix it"}
+				_, err := c.Edit(r)
+				return r.Model, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var model string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				var fields struct{ Model string }
+				json.NewDecoder(r.Body).Decode(&fields)
+				mu.Lock()
+				model = fields.Model
+				mu.Unlock()
+				w.Write([]byte(body))
+			})
+
+			if _, err := tt.call(c); err == nil {
+				t.Fatal("error = nil, want the missing model error")
+			}
+
+			c.SetDefaultModel("default-model")
+			got, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("error = %v, want nil", err)
+			}
+
+			if model != "default-model" {
+				t.Errorf("model = %q, want %q", model, "default-model")
+			}
+
+			if got != "" {
+				t.Errorf("request model = %q, want it unchanged", got)
+			}
+		})
+	}
+}
+
+// TestNilContext tests that the methods taking
+// the ctx use the client's context if it is nil.
+func TestNilContext(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/audio/speech"):
+			w.Write([]byte("audio"))
+		case strings.HasSuffix(r.URL.Path, "/files"):
+			w.Write([]byte(`{"id":"file-abc","object":"file"}`))
+		default:
+			w.Write([]byte(`{"id":"run-abc","status":"completed"}`))
+		}
+	})
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{
+			name: "AudioSpeechLong",
+			fn: func() error {
+				r := &AudioSpeechRequest{
+					Model: "tts-1",
+					Input: "Hello. World.",
+					Voice: "alloy",
+				}
+				_, err := c.AudioSpeechLong(nil, r, nil)
+				return err
+			},
+		},
+		{
+			name: "FileUploadJSON",
+			fn: func() error {
+				records := []map[string]string{{"prompt": "Hi"}}
+				_, err := c.FileUploadJSON(nil, records, FilePurposeFineTune)
+				return err
+			},
+		},
+		{
+			name: "RunWait",
+			fn: func() error {
+				_, err := c.RunWait(nil, "thread-abc", "run-abc", time.Millisecond)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); err != nil {
+				t.Errorf("%s() error = %v", tt.name, err)
+			}
+		})
+	}
+}
+
+// The countingRequester counts the calls of its Flush method.
+type countingRequester struct {
+	flushes int
+}
+
+// Error returns nil.
+func (r *countingRequester) Error() error {
+	return nil
+}
+
+// Flush counts the call.
+func (r *countingRequester) Flush() {
+	r.flushes++
+}
+
+func TestTrack(t *testing.T) {
+	tests := []struct {
+		name   string
+		client func() *Client
+		flush  func(c *Client, r *countingRequester)
+	}{
+		{
+			name:   "zero client",
+			client: func() *Client { return &Client{} },
+			flush: func(c *Client, r *countingRequester) {
+				c.Track(r)
+				c.Flush()
+			},
+		},
+		{
+			name:   "tracked twice",
+			client: func() *Client { return New("test-key") },
+			flush: func(c *Client, r *countingRequester) {
+				if c.Track(r) != c.Track(r) {
+					t.Error("Track() returned different requesters")
+				}
+				c.Flush()
+			},
+		},
+		{
+			name:   "flushed by the caller",
+			client: func() *Client { return New("test-key") },
+			flush: func(c *Client, r *countingRequester) {
+				c.Track(r).Flush()
+				c.Flush()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, r := tt.client(), &countingRequester{}
+			tt.flush(c, r)
+
+			if r.flushes != 1 {
+				t.Errorf("flushes = %d, want 1", r.flushes)
+			}
+
+			// The flushed requester isn't tracked anymore.
+			c.Flush()
+			if r.flushes != 1 {
+				t.Errorf("flushes after Flush() = %d, want 1", r.flushes)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   error
+	}{
+		{
+			name:   "valid",
+			config: Config{APIKey: "test-key"},
+		},
+		{
+			name:   "no API key",
+			config: Config{},
+			want:   ErrNoAPIKey,
+		},
+		{
+			name:   "relative base URL",
+			config: Config{APIKey: "test-key", APIBaseURL: "/v1"},
+			want:   ErrInvalidAPIBaseURL,
+		},
+		{
+			name:   "plain HTTP",
+			config: Config{APIKey: "test-key", APIBaseURL: "http://host/v1"},
+			want:   ErrInsecureAPIBaseURL,
+		},
+		{
+			name: "insecure plain HTTP",
+			config: Config{
+				APIKey:       "test-key",
+				APIBaseURL:   "http://host/v1",
+				InsecureHTTP: true,
+			},
+		},
+		{
+			name: "plain HTTP to localhost",
+			config: Config{
+				APIKey:     "test-key",
+				APIBaseURL: "http://localhost/v1",
+			},
+		},
+		{
+			name:   "invalid org ID",
+			config: Config{APIKey: "test-key", OrgID: "abc"},
+			want:   ErrInvalidOrgID,
+		},
+		{
+			name:   "negative parallel tasks",
+			config: Config{APIKey: "test-key", ParallelTasks: -1},
+			want:   ErrInvalidParallelTasks,
+		},
+		{
+			name:   "negative stream buffer size",
+			config: Config{APIKey: "test-key", StreamBufferSize: -1},
+			want:   ErrInvalidStreamBufferSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+
+			// The client is checked by the same validator,
+			// the invalid configuration is kept by the New.
+			c := New(tt.config)
+			if err := c.Error(); !errors.Is(err, tt.want) {
+				t.Errorf("Error() error = %v, want %v", err, tt.want)
+			}
+
+			errs := c.ValidateConfig()
+			if tt.want == nil && len(errs) != 0 {
+				t.Errorf("ValidateConfig() = %v, want nil", errs)
+			} else if tt.want != nil && (len(errs) == 0 || errs[0] != tt.want) {
+				t.Errorf("ValidateConfig() = %v, want [%v]", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	noop := Middleware(
+		func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+			return next.RoundTrip(req)
+		},
+	)
+	c := New(Config{
+		APIKey:      "test-key",
+		HTTPHeaders: http.Header{"X-Tenant": {"a"}},
+	})
+	c.Use(noop)
+
+	clone := c.Clone(WithAPIKey("new-key"), WithMiddleware(noop))
+	clone.HTTPHeaders().Set("X-Tenant", "b")
+
+	if c.apiKey != "test-key" {
+		t.Errorf("apiKey = %q, want %q", c.apiKey, "test-key")
+	}
+
+	if clone.apiKey != "new-key" {
+		t.Errorf("clone apiKey = %q, want %q", clone.apiKey, "new-key")
+	}
+
+	if got := c.HTTPHeaders().Get("X-Tenant"); got != "a" {
+		t.Errorf("X-Tenant = %q, want %q", got, "a")
+	}
+
+	if len(c.middlewares) != 1 || len(clone.middlewares) != 2 {
+		t.Errorf("middlewares = %d and %d, want 1 and 2",
+			len(c.middlewares), len(clone.middlewares))
+	}
+
+	// The clone shares the connection pool of the original, the new
+	// middleware chain of the clone wraps the same transport.
+	if clone.transport != c.transport {
+		t.Error("clone transport differs from the original")
+	}
+
+	if c.Clone(WithAPIKey("new-key")).HTTPClient() != c.HTTPClient() {
+		t.Error("clone HTTPClient() differs from the original")
+	}
+}
+
+func TestTransportTuning(t *testing.T) {
+	custom := &http.Client{Transport: &http.Transport{}}
+
+	tests := []struct {
+		name   string
+		config Config
+		tuned  bool
+	}{
+		{
+			name:   "default",
+			config: Config{APIKey: "test-key"},
+		},
+		{
+			name:   "force HTTP/2",
+			config: Config{APIKey: "test-key", ForceHTTP2: true},
+			tuned:  true,
+		},
+		{
+			name: "idle connections",
+			config: Config{
+				APIKey:              "test-key",
+				MaxIdleConnsPerHost: 32,
+			},
+			tuned: true,
+		},
+		{
+			name: "user's HTTPClient",
+			config: Config{
+				APIKey:              "test-key",
+				HTTPClient:          custom,
+				ForceHTTP2:          true,
+				MaxIdleConnsPerHost: 32,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(tt.config)
+			transport, ok := c.HTTPClient().Transport.(*http.Transport)
+			if !tt.tuned {
+				if ok && transport.MaxIdleConnsPerHost == 32 {
+					t.Error("the transport is tuned, want it unchanged")
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("Transport = %T, want *http.Transport",
+					c.HTTPClient().Transport)
+			}
+
+			if !transport.ForceAttemptHTTP2 {
+				t.Error("ForceAttemptHTTP2 = false, want true")
+			}
+
+			want := g.Value(tt.config.MaxIdleConnsPerHost,
+				http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost)
+			if transport.MaxIdleConnsPerHost != want {
+				t.Errorf("MaxIdleConnsPerHost = %d, want %d",
+					transport.MaxIdleConnsPerHost, want)
+			}
+		})
+	}
+}
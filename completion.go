@@ -2,8 +2,12 @@ package openai
 
 import (
 	"strings"
+	"time"
 )
 
+// maxStopSequences is the maximum number of the stop sequences.
+const maxStopSequences = 4
+
 // Check if CompletionRequest implements Requester interface.
 var _ Requester = (*CompletionRequest)(nil)
 
@@ -61,14 +65,66 @@ type CompletionRequest struct {
 
 	// User allows to specify a user ID for tracking purposes.
 	User string `json:"user,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
+}
+
+// CompletionOption sets a parameter of the completion
+// requests created by the CompletionMulti.
+type CompletionOption func(*CompletionRequest)
+
+// WithCompletionMaxTokens sets the maximum number of tokens of the completion.
+func WithCompletionMaxTokens(n int) CompletionOption {
+	return func(r *CompletionRequest) {
+		r.MaxTokens = n
+	}
+}
+
+// WithCompletionTemperature sets the sampling temperature of the completion.
+func WithCompletionTemperature(temperature float64) CompletionOption {
+	return func(r *CompletionRequest) {
+		r.Temperature = temperature
+	}
+}
+
+// WithCompletionTopP sets the nucleus sampling probability of the completion.
+func WithCompletionTopP(topP float64) CompletionOption {
+	return func(r *CompletionRequest) {
+		r.TopP = topP
+	}
+}
+
+// WithCompletionStop sets the sequences where the completion stops.
+func WithCompletionStop(stop ...string) CompletionOption {
+	return func(r *CompletionRequest) {
+		r.Stop = stop
+	}
+}
+
+// WithCompletionUser sets the ID of the end-user of the completion.
+func WithCompletionUser(user string) CompletionOption {
+	return func(r *CompletionRequest) {
+		r.User = user
+	}
 }
 
 // CompletionChoice is a single completion choice.
 type CompletionChoice struct {
-	Text         string `json:"text"`
-	Index        int    `json:"index"`
-	Logprobs     *int   `json:"logprobs"` // can be null
-	FinishReason string `json:"finish_reason"`
+	Text         string              `json:"text"`
+	Index        int                 `json:"index"`
+	Logprobs     *CompletionLogprobs `json:"logprobs"` // can be null
+	FinishReason string              `json:"finish_reason"`
+}
+
+// CompletionLogprobs is the log probability information of the choice,
+// it is returned if the Logprobs of the request is set.
+type CompletionLogprobs struct {
+	Tokens        []string             `json:"tokens"`
+	TokenLogprobs []float64            `json:"token_logprobs"`
+	TopLogprobs   []map[string]float64 `json:"top_logprobs"`
+	TextOffset    []int                `json:"text_offset"`
 }
 
 // CompletionUsage is the usage statistics for the completions API.
@@ -88,12 +144,91 @@ type CompletionResponse struct {
 	Usage   CompletionUsage    `json:"usage"`
 }
 
+// CompletionStreamDelta is an incremental part of
+// the completion received from the stream.
+type CompletionStreamDelta struct {
+	// Text is the next piece of the generated text.
+	Text string `json:"text"`
+
+	// Index is the index of the choice the text belongs to.
+	Index int `json:"index"`
+
+	// FinishReason is the reason the model stopped generating tokens,
+	// it is empty until the last delta of the choice.
+	FinishReason string `json:"finish_reason"`
+}
+
+// completionStreamChunk is a single event of the completion stream.
+type completionStreamChunk struct {
+	ID      string                  `json:"id"`
+	Object  string                  `json:"object"`
+	Created int                     `json:"created"`
+	Model   string                  `json:"model"`
+	Choices []CompletionStreamDelta `json:"choices"`
+	Error   *Error                  `json:"error,omitempty"`
+}
+
 // Error returns an error if the request is invalid.
 func (r *CompletionRequest) Error() error {
 	if r.Model == "" {
 		return ErrModelRequired
 	}
 
+	err := validateSampling(
+		r.Temperature,
+		r.TopP,
+		r.PresencePenalty,
+		r.FrequencyPenalty,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The server generates BestOf completions
+	// and returns the N best of them.
+	if r.N < 0 || (r.BestOf != 0 && r.BestOf < r.N) {
+		return ErrInvalidN
+	}
+
+	if err := validateStop(r.Stop); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The validateSampling returns an error if the sampling parameters are
+// out of the ranges accepted by the API. The zero values mean the default
+// values of the API, so they are always valid.
+func validateSampling(temperature, topP, presence, frequency float64) error {
+	if temperature < 0 || temperature > 2 {
+		return ErrInvalidTemperature
+	}
+
+	if topP < 0 || topP > 1 {
+		return ErrInvalidTopP
+	}
+
+	if presence < -2 || presence > 2 || frequency < -2 || frequency > 2 {
+		return ErrInvalidPenalty
+	}
+
+	return nil
+}
+
+// The validateStop returns an error if the stop sequences aren't
+// a string or a []string of up to maxStopSequences strings.
+func validateStop(stop interface{}) error {
+	switch stop := stop.(type) {
+	case nil, string:
+	case []string:
+		if len(stop) > maxStopSequences {
+			return ErrTooManyStopSequences
+		}
+	default:
+		return ErrInvalidStop
+	}
+
 	return nil
 }
 
@@ -102,6 +237,11 @@ func (r *CompletionRequest) Error() error {
 func (r *CompletionRequest) Flush() {
 }
 
+// The timeout returns the timeout of the request.
+func (r *CompletionRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
 // Text returns the generated text.
 func (r *CompletionResponse) Text() string {
 	var sb strings.Builder
@@ -119,3 +259,43 @@ func (r *CompletionResponse) Text() string {
 
 	return sb.String()
 }
+
+// BestChoice returns the choice with the highest cumulative log
+// probability if the log probabilities are returned, or the first
+// choice with a non-blank text otherwise. It returns nil if there
+// are no choices.
+func (r *CompletionResponse) BestChoice() *CompletionChoice {
+	var best *CompletionChoice
+	var bestScore float64
+	for i := range r.Choices {
+		choice := &r.Choices[i]
+		if choice.Logprobs == nil {
+			continue
+		}
+
+		score := 0.0
+		for _, lp := range choice.Logprobs.TokenLogprobs {
+			score += lp
+		}
+
+		if best == nil || score > bestScore {
+			best, bestScore = choice, score
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+
+	for i := range r.Choices {
+		if strings.TrimSpace(r.Choices[i].Text) != "" {
+			return &r.Choices[i]
+		}
+	}
+
+	if len(r.Choices) == 0 {
+		return nil
+	}
+
+	return &r.Choices[0]
+}
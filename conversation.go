@@ -0,0 +1,133 @@
+package openai
+
+import "sync"
+
+// ConversationManager maintains the history of a multi-turn chat. The
+// system prompt is always the first message, and the oldest non-system
+// messages are evicted when the history doesn't fit into the context
+// window of the model. The tokens are counted by the TokenEstimator,
+// which is a heuristic estimate unless a Tokenizer is registered, so
+// leave a margin in the maxContextTokens. It is safe for concurrent use.
+//
+// Example usage:
+//
+//	conv := openai.NewConversationManager(
+//	    "You are a helpful assistant.",
+//	    "gpt-4o",
+//	    8192,
+//	).Attach(client)
+//
+//	conv.AddUserMessage("Hello!")
+//	resp, err := conv.SendAndRecord()
+type ConversationManager struct {
+	mu sync.Mutex
+
+	model            string
+	maxContextTokens int
+	estimator        TokenEstimator
+	messages         []ChatCompletionMessage
+	client           *Client
+}
+
+// NewConversationManager creates a new conversation for the model.
+// The systemPrompt can be empty if the conversation has no system
+// message. The maxContextTokens is the size of the context window,
+// zero or negative value means no limit.
+func NewConversationManager(
+	systemPrompt, model string,
+	maxContextTokens int,
+) *ConversationManager {
+	cm := &ConversationManager{
+		model:            model,
+		maxContextTokens: maxContextTokens,
+		estimator:        TokenEstimator{Model: model},
+		messages:         []ChatCompletionMessage{},
+	}
+
+	if systemPrompt != "" {
+		cm.messages = append(cm.messages, ChatCompletionMessage{
+			Role:    "system",
+			Content: systemPrompt,
+		})
+	}
+
+	return cm
+}
+
+// Attach sets the client used to send the conversation.
+func (cm *ConversationManager) Attach(client *Client) *ConversationManager {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.client = client
+	return cm
+}
+
+// AddUserMessage appends the message of the user.
+func (cm *ConversationManager) AddUserMessage(content string) {
+	cm.add(ChatCompletionMessage{Role: "user", Content: content})
+}
+
+// AddAssistantMessage appends the message of the assistant.
+func (cm *ConversationManager) AddAssistantMessage(content string) {
+	cm.add(ChatCompletionMessage{Role: "assistant", Content: content})
+}
+
+// AddToolResultMessage appends the result of the tool call.
+func (cm *ConversationManager) AddToolResultMessage(
+	toolCallID, content string,
+) {
+	cm.add(ChatCompletionMessage{
+		Role:       "tool",
+		Content:    content,
+		ToolCallID: toolCallID,
+	})
+}
+
+// Messages returns a copy of the conversation history.
+func (cm *ConversationManager) Messages() []ChatCompletionMessage {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	return append([]ChatCompletionMessage{}, cm.messages...)
+}
+
+// SendAndRecord sends the conversation to the chat completion
+// endpoint with the attached client and appends the reply of
+// the assistant to the history.
+func (cm *ConversationManager) SendAndRecord() (*ChatCompletionResponse, error) {
+	cm.mu.Lock()
+	client := cm.client
+	r := &ChatCompletionRequest{
+		Model:    cm.model,
+		Messages: append([]ChatCompletionMessage{}, cm.messages...),
+	}
+	cm.mu.Unlock()
+
+	if client == nil {
+		return nil, ErrClientRequired
+	}
+
+	resp, err := client.ChatCompletion(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) > 0 {
+		cm.add(resp.Choices[0].Message)
+	}
+
+	return resp, nil
+}
+
+// The add appends the message and evicts the oldest
+// non-system messages if the history is too long.
+func (cm *ConversationManager) add(m ChatCompletionMessage) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.messages = append(cm.messages, m)
+	if cm.maxContextTokens > 0 {
+		cm.messages = cm.estimator.Truncate(cm.messages, cm.maxContextTokens)
+	}
+}
@@ -2,17 +2,19 @@ package openai
 
 import (
 	"strings"
+	"time"
 )
 
 // Check if EditRequest implements Requester interface.
 var _ Requester = (*EditRequest)(nil)
 
 type EditRequest struct {
-	Instruction string  `json:"instruction"`
-	Input       string  `json:"input,omitempty"`
-	Model       string  `json:"model"`
-	Temperature float64 `json:"temperature,omitempty"`
-	TopP        float64 `json:"top_p,omitempty"`
+	Instruction string        `json:"instruction"`
+	Input       string        `json:"input,omitempty"`
+	Model       string        `json:"model"`
+	Temperature float64       `json:"temperature,omitempty"`
+	TopP        float64       `json:"top_p,omitempty"`
+	Timeout     time.Duration `json:"-"`
 }
 
 type EditChoice struct {
@@ -52,6 +54,11 @@ func (r *EditRequest) Error() error {
 func (r *EditRequest) Flush() {
 }
 
+// The timeout returns the timeout of the request.
+func (r *EditRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
 func (r *EditResponse) Text() string {
 	var sb strings.Builder
 
@@ -1,8 +1,64 @@
 package openai
 
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/goloop/g"
+)
+
+// embeddingBatchSize is the maximum number of inputs
+// in a single request of the Embedding API.
+const embeddingBatchSize = 2048
+
 // Check if EmbeddingRequest implements Requester interface.
 var _ Requester = (*EmbeddingRequest)(nil)
 
+// Check if TextInput and ImageInput implement
+// EmbeddingInputItem interface.
+var (
+	_ EmbeddingInputItem = TextInput{}
+	_ EmbeddingInputItem = ImageInput{}
+)
+
+var (
+	// textEmbeddingModels is a list of the known embedding models
+	// that accept the text inputs only.
+	textEmbeddingModels = []string{
+		"text-embedding-ada-002",
+		"text-embedding-3-small",
+		"text-embedding-3-large",
+	}
+
+	// multiModalEmbeddingModels is a list of embedding models that accept
+	// image inputs alongside text. It can be extended with the
+	// AddMultiModalEmbeddingModels function.
+	multiModalEmbeddingModels   = []string{}
+	multiModalEmbeddingModelsMu sync.RWMutex
+)
+
+// EmbeddingInputItem is a single item of the multi-modal input
+// of the embedding request. It is implemented by TextInput and
+// ImageInput types.
+type EmbeddingInputItem interface {
+	json.Marshaler
+	Error() error
+}
+
+// TextInput is a text item of the multi-modal embedding input.
+type TextInput struct {
+	// The text to generate embeddings for.
+	Text string
+}
+
+// ImageInput is an image item of the multi-modal embedding input.
+type ImageInput struct {
+	// The URL of the image (or base64 encoded data URL)
+	// to generate embeddings for.
+	URL string
+}
+
 // EmbeddingRequest represents a request to the OpenAI Embedding API.
 type EmbeddingRequest struct {
 	// The model ID to use for the request. This is required.
@@ -17,6 +73,10 @@ type EmbeddingRequest struct {
 	// A unique identifier representing the end-user. This can help OpenAI to
 	// monitor and detect abuse. This is optional.
 	User string `json:"user,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
 }
 
 // Embedding represents an individual embedding in the response
@@ -63,8 +123,58 @@ func (r *EmbeddingRequest) Error() error {
 		return ErrModelRequired
 	}
 
-	if r.Input == nil {
+	switch input := r.Input.(type) {
+	case nil:
 		return ErrInputRequired
+	case string:
+		if input == "" {
+			return ErrEmptyInput
+		}
+	case []string:
+		if len(input) == 0 {
+			return ErrEmptyInput
+		}
+
+		for _, v := range input {
+			if v == "" {
+				return ErrEmptyInput
+			}
+		}
+	case []int:
+		if err := validateTokens(input); err != nil {
+			return err
+		}
+	case [][]int:
+		if len(input) == 0 {
+			return ErrEmptyInput
+		}
+
+		for _, tokens := range input {
+			if err := validateTokens(tokens); err != nil {
+				return err
+			}
+		}
+	case []EmbeddingInputItem:
+		// Multi-modal inputs can be used with specific models only.
+		if !ModelSupportsMultiModalEmbedding(r.Model) {
+			return ErrMultiModalNotSupported
+		}
+
+		if len(input) == 0 {
+			return ErrEmptyInput
+		}
+
+		for _, item := range input {
+			if item == nil {
+				return ErrInputRequired
+			}
+
+			if err := item.Error(); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrInvalidInput
 	}
 
 	return nil
@@ -74,3 +184,123 @@ func (r *EmbeddingRequest) Error() error {
 // This is here to satisfy the Requester interface.
 func (r *EmbeddingRequest) Flush() {
 }
+
+// The timeout returns the timeout of the request.
+func (r *EmbeddingRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// SetInputStrings sets the input of the request
+// to the list of texts and returns the request.
+func (r *EmbeddingRequest) SetInputStrings(strs []string) *EmbeddingRequest {
+	r.Input = strs
+	return r
+}
+
+// SetInputTokens sets the input of the request to the list
+// of token arrays and returns the request.
+func (r *EmbeddingRequest) SetInputTokens(tokens [][]int) *EmbeddingRequest {
+	r.Input = tokens
+	return r
+}
+
+// SetMultiModalInput sets the input of the request to the list of
+// text and image items and returns the request.
+func (r *EmbeddingRequest) SetMultiModalInput(
+	items []EmbeddingInputItem,
+) *EmbeddingRequest {
+	r.Input = items
+	return r
+}
+
+// The validateTokens returns an error if the token array
+// is empty or contains negative tokens.
+func validateTokens(tokens []int) error {
+	if len(tokens) == 0 {
+		return ErrEmptyInput
+	}
+
+	for _, t := range tokens {
+		if t < 0 {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
+
+// Error returns an error if the text input is invalid.
+func (t TextInput) Error() error {
+	if t.Text == "" {
+		return ErrInputRequired
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the text input as
+// {"type": "text", "text": "..."} object.
+func (t TextInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}{
+		Type: "text",
+		Text: t.Text,
+	})
+}
+
+// Error returns an error if the image input is invalid.
+func (i ImageInput) Error() error {
+	if i.URL == "" {
+		return ErrImageRequired
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the image input as
+// {"type": "image_url", "image_url": {"url": "..."}} object.
+func (i ImageInput) MarshalJSON() ([]byte, error) {
+	type imageURL struct {
+		URL string `json:"url"`
+	}
+
+	return json.Marshal(struct {
+		Type     string   `json:"type"`
+		ImageURL imageURL `json:"image_url"`
+	}{
+		Type:     "image_url",
+		ImageURL: imageURL{URL: i.URL},
+	})
+}
+
+// ModelSupportsMultiModalEmbedding returns true if the model accepts
+// image inputs alongside text for generating embeddings. The known
+// text-only models, e.g. "text-embedding-3-small", don't accept them
+// unless they are added by the AddMultiModalEmbeddingModels function.
+// The other models aren't rejected, the API checks them itself.
+func ModelSupportsMultiModalEmbedding(model string) bool {
+	multiModalEmbeddingModelsMu.RLock()
+	defer multiModalEmbeddingModelsMu.RUnlock()
+
+	return g.In(model, multiModalEmbeddingModels...) ||
+		!g.In(model, textEmbeddingModels...)
+}
+
+// AddMultiModalEmbeddingModels marks the models as supporting multi-modal
+// embedding inputs. It can be used for models of compatible APIs
+// (proxies, self-hosted servers, etc.) that accept image inputs.
+func AddMultiModalEmbeddingModels(models ...string) {
+	multiModalEmbeddingModelsMu.Lock()
+	defer multiModalEmbeddingModelsMu.Unlock()
+
+	for _, model := range models {
+		if !g.In(model, multiModalEmbeddingModels...) {
+			multiModalEmbeddingModels = append(
+				multiModalEmbeddingModels,
+				model,
+			)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEmbeddingMultiModalInput(t *testing.T) {
+	AddMultiModalEmbeddingModels("text-embedding-ada-002")
+
+	items := []EmbeddingInputItem{
+		TextInput{Text: "A cat"},
+		ImageInput{URL: "https://example.com/cat.png"},
+	}
+
+	tests := []struct {
+		name  string
+		model string
+		err   error
+	}{
+		{name: "unknown model", model: "multimodal-embedding-1"},
+		{
+			name:  "text-only model",
+			model: "text-embedding-3-small",
+			err:   ErrMultiModalNotSupported,
+		},
+		{name: "added model", model: "text-embedding-ada-002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := (&EmbeddingRequest{Model: tt.model}).SetMultiModalInput(items)
+			if err := r.Error(); !errors.Is(err, tt.err) {
+				t.Fatalf("Error() = %v, want %v", err, tt.err)
+			}
+
+			data, err := json.Marshal(r.Input)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			want := `[{"type":"text","text":"A cat"},` +
+				`{"type":"image_url","image_url":` +
+				`{"url":"https://example.com/cat.png"}}]`
+			if string(data) != want {
+				t.Errorf("input = %s, want %s", data, want)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package openai
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var (
 	ErrNoAPIKey     = errors.New("no API key")
@@ -8,21 +13,104 @@ var (
 	ErrNoHTTPClient = errors.New("no HTTP client")
 	ErrNoContext    = errors.New("no context")
 
+	ErrInvalidAPIBaseURL       = errors.New("invalid API base URL")
+	ErrInvalidOrgID            = errors.New("invalid organization ID")
+	ErrInsecureAPIBaseURL      = errors.New("insecure API base URL, use https")
+	ErrInvalidParallelTasks    = errors.New("invalid number of parallel tasks")
+	ErrInvalidTimeout          = errors.New("invalid timeout")
+	ErrInvalidStreamBufferSize = errors.New("invalid stream buffer size")
+	ErrProxyURLInvalid         = errors.New("invalid proxy URL")
+	ErrCannotConfigureProxy    = errors.New("cannot configure proxy of HTTP client")
+
+	ErrClientRequired = errors.New("client is required")
+
+	ErrResourceRequired   = errors.New("resource name is required")
+	ErrDeploymentRequired = errors.New("deployment is required")
+
 	ErrRequestTimedOut = errors.New("request timed out")
 	ErrPromptRequired  = errors.New("prompt is required")
+	ErrPromptTooLong   = errors.New("prompt is too long")
 	ErrMessageRequired = errors.New("message is required")
 	ErrInputRequired   = errors.New("input is required")
+	ErrEmptyInput      = errors.New("input is empty")
+	ErrInvalidInput    = errors.New("invalid input")
 
-	ErrModelRequired = errors.New("model is required")
-	ErrImageRequired = errors.New("image is required")
+	ErrToolCallIDRequired = errors.New("tool call ID is required")
+	ErrNameRequired       = errors.New("name is required")
+
+	ErrModelRequired     = errors.New("model is required")
+	ErrImageRequired     = errors.New("image is required")
+	ErrAssistantRequired = errors.New("assistant is required")
 
 	ErrInvalidResponseFormat = errors.New("invalid response format")
+	ErrNoJSON                = errors.New("no JSON found")
+	ErrNoBase64Data          = errors.New("no base64 data")
+	ErrSchemaRequired        = errors.New("schema is required")
+	ErrInvalidSchema         = errors.New("invalid schema")
 	ErrInvalidSize           = errors.New("invalid size")
+	ErrInvalidQuality        = errors.New("invalid quality")
+	ErrInvalidStyle          = errors.New("invalid style")
+	ErrImageNotPNG           = errors.New("image is not a PNG")
+	ErrImageNotRGBA          = errors.New("image is not an RGBA PNG")
+	ErrImageNotSquare        = errors.New("image is not square")
 	ErrInvalidRole           = errors.New("invalid role")
+	ErrInvalidContent        = errors.New("invalid content")
+	ErrInvalidTopLogProbs    = errors.New("invalid top log probabilities")
+	ErrInvalidTemperature    = errors.New("invalid temperature")
+	ErrInvalidTopP           = errors.New("invalid top p")
+	ErrInvalidPenalty        = errors.New("invalid penalty")
+	ErrInvalidN              = errors.New("invalid number of choices")
+	ErrInvalidStop           = errors.New("stop must be a string or []string")
+	ErrInvalidLogitBias      = errors.New("invalid logit bias")
+	ErrTooManyStopSequences  = errors.New("too many stop sequences, the limit is 4")
 	ErrInstructionRequired   = errors.New("instruction is required")
 
+	ErrTrainingFileRequired          = errors.New("training file is required")
+	ErrInvalidNEpochs                = errors.New("invalid number of epochs")
+	ErrInvalidBatchSize              = errors.New("invalid batch size")
+	ErrInvalidLearningRateMultiplier = errors.New("invalid learning rate multiplier")
+	ErrInvalidPromptLossWeight       = errors.New("invalid prompt loss weight")
+	ErrInvalidClassificationNClasses = errors.New("invalid number of classes")
+	ErrPositiveClassRequired         = errors.New("positive class is required")
+	ErrModelNotReady                 = errors.New("fine-tuned model is not ready")
+	ErrNoModelPermission             = errors.New("model has no permissions")
+
 	ErrFileRequired    = errors.New("file is required")
 	ErrPurposeRequired = errors.New("purpose is required")
+	ErrRecordsNotSlice = errors.New("records must be a slice or an array")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrChecksumFailed  = errors.New("checksum mismatch")
+	ErrPartialDelete   = errors.New("some files are not deleted")
+	ErrFileProcessing  = errors.New("file processing failed")
+
+	ErrCannotGenerateUniqueFilename = errors.New(
+		"cannot generate unique filename",
+	)
+
+	ErrTokenizerUnavailable = errors.New("no tokenizer is registered")
+
+	ErrInputTooLong           = errors.New("input is too long")
+	ErrMultiModalNotSupported = errors.New("model doesn't support multi-modal input")
+	ErrUnsupportedAudioFormat = errors.New("unsupported audio format")
+	ErrInvalidAudioFormat     = errors.New("invalid audio format")
+	ErrAudioFileTooLarge      = errors.New("audio file is too large")
+	ErrInvalidVoice           = errors.New("invalid voice")
+	ErrInvalidSpeed           = errors.New("invalid speed")
+	ErrTooManyTools           = errors.New("too many tools")
+
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+
+	ErrInvalidEndpoint         = errors.New("invalid endpoint")
+	ErrInvalidCompletionWindow = errors.New("invalid completion window")
+
+	// The errors returned by the OpenAI API server. They aren't returned
+	// directly, but match the *APIError with errors.Is:
+	//
+	//	if errors.Is(err, openai.ErrRateLimit) { ... }
+	ErrRateLimit    = errors.New("rate limit exceeded")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrServerError  = errors.New("server error")
 )
 
 // Error describes an error data that can be
@@ -39,3 +127,174 @@ type Error struct {
 type ErrorResponse struct {
 	Error Error `json:"error"` // error details
 }
+
+// Check if *APIError implements error interface.
+var _ error = (*APIError)(nil)
+
+// APIError is the error returned when the OpenAI API server responds
+// with a non-success status code. It can be inspected with errors.As:
+//
+//	var apiErr *openai.APIError
+//	if errors.As(err, &apiErr) && apiErr.IsRateLimit() {
+//	    time.Sleep(apiErr.Meta.RetryAfter())
+//	}
+type APIError struct {
+	StatusCode int           // HTTP status code of the response
+	Code       string        // error code
+	Type       string        // high level error category
+	Message    string        // human-readable text about the error
+	Param      string        // which parameter the error is related to
+	Meta       *ResponseMeta // metadata of the response, e.g. the rate limits
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf(
+		"non-success status code %d: %s",
+		e.StatusCode,
+		e.Message,
+	)
+}
+
+// Is reports whether the error matches the target sentinel error,
+// so the API errors can be checked with errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimit:
+		return e.IsRateLimit()
+	case ErrUnauthorized:
+		return e.IsAuthError()
+	case ErrNotFound:
+		return e.IsNotFound()
+	case ErrServerError:
+		return e.IsServerError()
+	}
+
+	return false
+}
+
+// IsRateLimit returns true if the request was rejected
+// because the rate limit or the quota was exceeded.
+func (e *APIError) IsRateLimit() bool {
+	return e.StatusCode == http.StatusTooManyRequests ||
+		e.Code == "rate_limit_exceeded"
+}
+
+// IsAuthError returns true if the request was rejected
+// because of invalid credentials or missing permissions.
+func (e *APIError) IsAuthError() bool {
+	return e.StatusCode == http.StatusUnauthorized ||
+		e.StatusCode == http.StatusForbidden ||
+		e.Code == "invalid_api_key"
+}
+
+// IsServerError returns true if the request
+// failed due to an error on the server side.
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsNotFound returns true if the requested
+// resource (model, file, etc.) doesn't exist.
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound ||
+		e.Code == "model_not_found"
+}
+
+// MultiError collects the errors of the requests that are sent
+// in parallel, e.g. by the Models method.
+type MultiError struct {
+	Errors []error // errors in the order of the requests
+}
+
+// Error implements the error interface.
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the collected errors,
+// so errors.Is and errors.As check all of them.
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// IndexedError is the error of a single request of the batch.
+type IndexedError struct {
+	Index int   // index of the request in the batch
+	Err   error // error of the request
+}
+
+// Error implements the error interface.
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("request %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the request.
+func (e IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError collects the errors of the failed requests of the batch
+// that is sent in parallel, e.g. by the EmbeddingBatch method.
+type BatchError struct {
+	Errors []IndexedError // errors ordered by the request index
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf(
+		"%d of the requests failed: %s",
+		len(e.Errors),
+		strings.Join(messages, "; "),
+	)
+}
+
+// Unwrap returns the errors of the requests,
+// so errors.Is and errors.As check all of them.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// PartialDeleteError is returned by the bulk deletion, e.g. by the
+// DeleteFiles method, if some of the files are deleted and the others
+// failed. It matches ErrPartialDelete with errors.Is.
+type PartialDeleteError struct {
+	Deleted int      // number of the deleted files
+	Failed  []string // IDs of the files that failed to delete
+	Err     error    // first error of the failed files
+}
+
+// Error implements the error interface.
+func (e *PartialDeleteError) Error() string {
+	return fmt.Sprintf(
+		"%d files deleted, %d failed: %v",
+		e.Deleted,
+		len(e.Failed),
+		e.Err,
+	)
+}
+
+// Is reports whether the target is ErrPartialDelete.
+func (e *PartialDeleteError) Is(target error) bool {
+	return target == ErrPartialDelete
+}
+
+// Unwrap returns the first error of the failed files.
+func (e *PartialDeleteError) Unwrap() error {
+	return e.Err
+}
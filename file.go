@@ -1,10 +1,43 @@
 package openai
 
-import "os"
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxJSONLLineSize is the maximum size of a line of the JSON Lines file.
+const maxJSONLLineSize = 16 << 20
 
 // Check if FileUploadRequest implements Requester interface.
 var _ Requester = (*FileUploadRequest)(nil)
 
+// FilePurpose is the intended purpose of the uploaded file.
+type FilePurpose string
+
+const (
+	// FilePurposeFineTune is used for the fine-tuning files.
+	FilePurposeFineTune FilePurpose = "fine-tune"
+
+	// FilePurposeAssistants is used for the files of the assistants.
+	FilePurposeAssistants FilePurpose = "assistants"
+
+	// FilePurposeBatch is used for the input files of the Batch API.
+	FilePurposeBatch FilePurpose = "batch"
+)
+
 // FileDeleteResponse represents the response from the OpenAI File API
 // when a file deletion request is made.
 type FileDeleteResponse struct {
@@ -37,8 +70,22 @@ type FileDetails struct {
 
 	// The purpose of the file.
 	Purpose string `json:"purpose"`
+
+	// The processing status of the file:
+	// uploaded, processed or error.
+	Status string `json:"status,omitempty"`
+
+	// The details of the error status of the file.
+	StatusDetails string `json:"status_details,omitempty"`
 }
 
+// The statuses of the uploaded file.
+const (
+	FileStatusUploaded  = "uploaded"
+	FileStatusProcessed = "processed"
+	FileStatusError     = "error"
+)
+
 type FilesData []*FileDetails
 
 // FileResponse represents the response from the OpenAI File API.
@@ -60,6 +107,15 @@ type FileUploadRequest struct {
 	// The intended purpose of the uploaded documents.
 	// This is a required field. "fine-tune" is used for Fine-tuning.
 	Purpose string `json:"purpose"`
+
+	// OnProgress is called while the request body is sent with the number
+	// of bytes written so far and the total size of the body. It isn't
+	// sent to the API. This is an optional field.
+	OnProgress func(bytesWritten, totalBytes int64) `json:"-"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
 }
 
 // FileUploadResponse represents the response from the OpenAI File API
@@ -148,3 +204,196 @@ func (r *FileUploadRequest) CloseFile() {
 func (r *FileUploadRequest) Flush() {
 	r.CloseFile()
 }
+
+// The timeout returns the timeout of the request.
+func (r *FileUploadRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// The progressFunc returns the callback of the upload progress.
+func (r *FileUploadRequest) progressFunc() func(bytesWritten, totalBytes int64) {
+	return r.OnProgress
+}
+
+// JSONLValidationError is returned when a line
+// of the JSON Lines file is invalid.
+type JSONLValidationError struct {
+	Line int   // number of the line, starting from 1
+	Err  error // the problem of the line
+}
+
+// Error implements the error interface.
+func (e *JSONLValidationError) Error() string {
+	return fmt.Sprintf("invalid JSON Lines at line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the problem of the line.
+func (e *JSONLValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateJSONL checks that every line of the file is a JSON object,
+// and returns a *JSONLValidationError for the first invalid line. For
+// the "fine-tune" purpose, each object must also have the string
+// "prompt" and "completion" fields, or the "messages" array of the
+// chat format. The file is read from the start and rewound after.
+func (r *FileUploadRequest) ValidateJSONL() error {
+	return r.scanJSONL(func(line []byte) error {
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(line, &object); err != nil {
+			return err
+		}
+
+		if r.Purpose == string(FilePurposeFineTune) {
+			return validateFineTuneObject(object)
+		}
+
+		return nil
+	})
+}
+
+// ValidateJSONLStrict checks that every line of the file decodes into
+// the type of the schema, e.g. a struct or a pointer to a struct, and
+// has no fields that the schema doesn't have. It returns a
+// *JSONLValidationError for the first invalid line. The file is read
+// from the start and rewound after.
+func (r *FileUploadRequest) ValidateJSONLStrict(schema interface{}) error {
+	if schema == nil {
+		return ErrInvalidSchema
+	}
+
+	typ := reflect.TypeOf(schema)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return r.scanJSONL(func(line []byte) error {
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(reflect.New(typ).Interface())
+	})
+}
+
+// The scanJSONL calls the check for every non-blank line of the file,
+// the file is rewound before and after the scan.
+func (r *FileUploadRequest) scanJSONL(check func(line []byte) error) error {
+	if r.File == nil {
+		return ErrFileRequired
+	}
+
+	if _, err := r.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	defer r.File.Seek(0, io.SeekStart)
+
+	scanner := bufio.NewScanner(r.File)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := check(line); err != nil {
+			return &JSONLValidationError{Line: n, Err: err}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// The validateFineTuneObject checks the fields of the fine-tuning example.
+func validateFineTuneObject(object map[string]interface{}) error {
+	if messages, ok := object["messages"]; ok {
+		if _, ok := messages.([]interface{}); !ok {
+			return errors.New(`"messages" must be an array`)
+		}
+
+		return nil
+	}
+
+	for _, key := range []string{"prompt", "completion"} {
+		if _, ok := object[key].(string); !ok {
+			return fmt.Errorf("%q must be a string", key)
+		}
+	}
+
+	return nil
+}
+
+// FilterByPurpose returns a new list of the files with the purpose.
+func (data *FilesData) FilterByPurpose(purpose string) FilesData {
+	result := FilesData{}
+	for _, f := range *data {
+		if f.Purpose == purpose {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// TotalBytes returns the total size of the files in bytes.
+func (data *FilesData) TotalBytes() int {
+	total := 0
+	for _, f := range *data {
+		total += f.Bytes
+	}
+	return total
+}
+
+// SortByCreatedAt returns a new list of the files
+// sorted by the creation time.
+func (data *FilesData) SortByCreatedAt(ascending bool) FilesData {
+	result := append(FilesData{}, *data...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if ascending {
+			return result[i].CreatedAt < result[j].CreatedAt
+		}
+		return result[i].CreatedAt > result[j].CreatedAt
+	})
+	return result
+}
+
+// OldestFirst returns a new list of the files, the oldest file first.
+func (data *FilesData) OldestFirst() FilesData {
+	return data.SortByCreatedAt(true)
+}
+
+// NewestFirst returns a new list of the files, the newest file first.
+func (data *FilesData) NewestFirst() FilesData {
+	return data.SortByCreatedAt(false)
+}
+
+// DownloadOption is an option of the file download.
+type DownloadOption func(*downloadOptions)
+
+// The downloadOptions are the options of the file download.
+type downloadOptions struct {
+	checksum string
+}
+
+// WithChecksum verifies the downloaded content against the expected
+// checksum, a hex encoded MD5 or SHA256 sum. The algorithm is chosen
+// by the length of the checksum.
+func WithChecksum(expected string) DownloadOption {
+	return func(o *downloadOptions) {
+		o.checksum = strings.ToLower(expected)
+	}
+}
+
+// The checksumHash returns the hash for the expected checksum.
+func checksumHash(checksum string) (hash.Hash, error) {
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return nil, ErrInvalidChecksum
+	}
+
+	switch len(checksum) {
+	case md5.Size * 2:
+		return md5.New(), nil
+	case sha256.Size * 2:
+		return sha256.New(), nil
+	}
+
+	return nil, ErrInvalidChecksum
+}
@@ -0,0 +1,186 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDeleteFiles(t *testing.T) {
+	const files = `{"object":"list","data":[` +
+		`{"id":"file-a","purpose":"fine-tune"},` +
+		`{"id":"file-b","purpose":"fine-tune"},` +
+		`{"id":"file-c","purpose":"fine-tune"},` +
+		`{"id":"file-d","purpose":"assistants"}]}`
+
+	tests := []struct {
+		name      string
+		jobs      string
+		fineTunes string
+		want      []string
+	}{
+		{
+			name: "running job keeps its files",
+			jobs: `{"data":[` +
+				`{"id":"ftjob-1","status":"running","training_file":"file-b",` +
+				`"validation_file":"file-c"},` +
+				`{"id":"ftjob-2","status":"succeeded","training_file":"file-a"}]}`,
+			want: []string{"file-a"},
+		},
+		{
+			name: "legacy fine-tunes are checked",
+			jobs: `{"data":[]}`,
+			fineTunes: `{"data":[{"id":"ft-1","status":"pending",` +
+				`"training_files":[{"id":"file-a"}]}]}`,
+			want: []string{"file-b", "file-c"},
+		},
+		{
+			name: "legacy fine-tunes are unavailable",
+			jobs: `{"data":[]}`,
+			want: []string{"file-a", "file-b", "file-c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			deleted := []string{}
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodDelete:
+					mu.Lock()
+					id := strings.TrimPrefix(r.URL.Path, "/v1/files/")
+					deleted = append(deleted, id)
+					mu.Unlock()
+					w.Write([]byte(`{"id":"` + id + `","deleted":true}`))
+				case r.URL.Path == "/v1/files":
+					w.Write([]byte(files))
+				case r.URL.Path == "/v1/fine_tuning/jobs":
+					w.Write([]byte(tt.jobs))
+				case r.URL.Path == "/v1/fine-tunes" && tt.fineTunes != "":
+					w.Write([]byte(tt.fineTunes))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"error":{"message":"not found"}}`))
+				}
+			})
+
+			n, err := c.DeleteFiles("fine-tune")
+			if err != nil {
+				t.Fatalf("DeleteFiles() error = %v", err)
+			}
+
+			sort.Strings(deleted)
+			if n != len(tt.want) || strings.Join(deleted, ",") !=
+				strings.Join(tt.want, ",") {
+				t.Errorf("DeleteFiles() = %d, deleted %v, want %v",
+					n, deleted, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileUploadJSON(t *testing.T) {
+	type example struct {
+		Prompt string `json:"prompt"`
+	}
+
+	tests := []struct {
+		name    string
+		records interface{}
+		want    string
+		err     error
+	}{
+		{
+			name:    "slice",
+			records: []example{{Prompt: "a"}, {Prompt: "b"}},
+			want:    "{\"prompt\":\"a\"}\n{\"prompt\":\"b\"}\n",
+		},
+		{
+			name:    "array pointer",
+			records: &[1]example{{Prompt: "c"}},
+			want:    "{\"prompt\":\"c\"}\n",
+		},
+		{
+			name:    "not a slice",
+			records: example{Prompt: "d"},
+			err:     ErrRecordsNotSlice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("TMPDIR", dir)
+
+			var got, purpose string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				file, _, err := r.FormFile("file")
+				if err != nil {
+					t.Errorf("FormFile() error = %v", err)
+					return
+				}
+				defer file.Close()
+
+				data, _ := io.ReadAll(file)
+				got, purpose = string(data), r.FormValue("purpose")
+				w.Write([]byte(`{"id":"file-abc","object":"file"}`))
+			})
+
+			_, err := c.FileUploadJSON(nil, tt.records, FilePurposeFineTune)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("FileUploadJSON() error = %v, want %v", err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Errorf("uploaded %q, want %q", got, tt.want)
+			}
+
+			if tt.err == nil && purpose != string(FilePurposeFineTune) {
+				t.Errorf("purpose = %q", purpose)
+			}
+
+			// The temporary file is removed after the upload.
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("temporary files are left: %v", entries)
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Write([]byte(`{"id":"` + id + `","object":"file"}`))
+	}, WithParallelTasks(2))
+
+	data, err := c.Files("file-a", "file-b", "file-c")
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+
+	want := []string{"/v1/files/file-a", "/v1/files/file-b", "/v1/files/file-c"}
+	sort.Strings(paths)
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	// The details are in the order of the requested IDs.
+	for i, id := range []string{"file-a", "file-b", "file-c"} {
+		if data[i].ID != id {
+			t.Errorf("data[%d].ID = %q, want %q", i, data[i].ID, id)
+		}
+	}
+}
@@ -1,22 +1,31 @@
 package openai
 
+import (
+	"fmt"
+	"time"
+)
+
+// maxFineTuneEpochs is the maximum number of the training epochs.
+const maxFineTuneEpochs = 50
+
 // Check if FineTuneRequest implements Requester interface.
 var _ Requester = (*FineTuneRequest)(nil)
 
 // FineTuneRequest represents the request for a fine-tuning job.
 type FineTuneRequest struct {
-	TrainingFile                 string    `json:"training_file"`                            // ID of uploaded file with training data
-	ValidationFile               string    `json:"validation_file,omitempty"`                // ID of uploaded file with validation data
-	Model                        string    `json:"model,omitempty"`                          // Base model to fine-tune
-	NEpochs                      int       `json:"n_epochs,omitempty"`                       // Number of epochs for training
-	BatchSize                    int       `json:"batch_size,omitempty"`                     // Batch size for training
-	LearningRateMultiplier       float64   `json:"learning_rate_multiplier,omitempty"`       // Multiplier for the learning rate
-	PromptLossWeight             float64   `json:"prompt_loss_weight,omitempty"`             // Weight for loss on prompt tokens
-	ComputeClassificationMetrics bool      `json:"compute_classification_metrics,omitempty"` // If true, calculates classification-specific metrics
-	ClassificationNClasses       int       `json:"classification_n_classes,omitempty"`       // Number of classes in a classification task
-	ClassificationPositiveClass  string    `json:"classification_positive_class,omitempty"`  // Positive class in binary classification
-	ClassificationBetas          []float64 `json:"classification_betas,omitempty"`           // F-beta scores at the specified beta values
-	Suffix                       string    `json:"suffix,omitempty"`                         // Suffix for the fine-tuned model name
+	TrainingFile                 string        `json:"training_file"`                            // ID of uploaded file with training data
+	ValidationFile               string        `json:"validation_file,omitempty"`                // ID of uploaded file with validation data
+	Model                        string        `json:"model,omitempty"`                          // Base model to fine-tune
+	NEpochs                      int           `json:"n_epochs,omitempty"`                       // Number of epochs for training
+	BatchSize                    int           `json:"batch_size,omitempty"`                     // Batch size for training
+	LearningRateMultiplier       float64       `json:"learning_rate_multiplier,omitempty"`       // Multiplier for the learning rate
+	PromptLossWeight             float64       `json:"prompt_loss_weight,omitempty"`             // Weight for loss on prompt tokens
+	ComputeClassificationMetrics bool          `json:"compute_classification_metrics,omitempty"` // If true, calculates classification-specific metrics
+	ClassificationNClasses       int           `json:"classification_n_classes,omitempty"`       // Number of classes in a classification task
+	ClassificationPositiveClass  string        `json:"classification_positive_class,omitempty"`  // Positive class in binary classification
+	ClassificationBetas          []float64     `json:"classification_betas,omitempty"`           // F-beta scores at the specified beta values
+	Suffix                       string        `json:"suffix,omitempty"`                         // Suffix for the fine-tuned model name
+	Timeout                      time.Duration `json:"-"`                                        // Timeout of the request, the client's one if zero
 }
 
 // FineTuneEvent represents an event of a fine-tuning job.
@@ -82,6 +91,37 @@ type FineTuneEventListResponse struct {
 
 // Error returns an error if the request is invalid.
 func (ftr *FineTuneRequest) Error() error {
+	if ftr.TrainingFile == "" {
+		return ErrTrainingFileRequired
+	}
+
+	// The zero values of the hyperparameters
+	// mean the default values of the API.
+	if ftr.NEpochs < 0 || ftr.NEpochs > maxFineTuneEpochs {
+		return ErrInvalidNEpochs
+	}
+
+	if ftr.BatchSize < 0 {
+		return ErrInvalidBatchSize
+	}
+
+	if ftr.LearningRateMultiplier < 0 {
+		return ErrInvalidLearningRateMultiplier
+	}
+
+	if ftr.PromptLossWeight < 0 || ftr.PromptLossWeight > 1 {
+		return ErrInvalidPromptLossWeight
+	}
+
+	if ftr.ComputeClassificationMetrics && ftr.ClassificationNClasses < 2 {
+		return ErrInvalidClassificationNClasses
+	}
+
+	// The binary classification requires the positive class.
+	if ftr.ClassificationNClasses == 2 && ftr.ClassificationPositiveClass == "" {
+		return ErrPositiveClassRequired
+	}
+
 	return nil
 }
 
@@ -89,3 +129,122 @@ func (ftr *FineTuneRequest) Error() error {
 // It here to implement the Requester interface.
 func (ftr *FineTuneRequest) Flush() {
 }
+
+// The timeout returns the timeout of the request.
+func (ftr *FineTuneRequest) timeout() time.Duration {
+	return ftr.Timeout
+}
+
+// FilterByStatus returns a new list of the fine-tuning jobs with the status.
+func (data *FineTunesData) FilterByStatus(status string) FineTunesData {
+	result := FineTunesData{}
+	for _, ft := range *data {
+		if ft.Status == status {
+			result = append(result, ft)
+		}
+	}
+	return result
+}
+
+// ErrorEvents returns a new list of the events with the "error" level.
+func (data *FineTuneEventsData) ErrorEvents() FineTuneEventsData {
+	return data.filterByLevel("error")
+}
+
+// InfoEvents returns a new list of the events with the "info" level.
+func (data *FineTuneEventsData) InfoEvents() FineTuneEventsData {
+	return data.filterByLevel("info")
+}
+
+// Latest returns the most recent event, or nil if there are no events.
+func (data *FineTuneEventsData) Latest() *FineTuneEvent {
+	var latest *FineTuneEvent
+	for _, e := range *data {
+		if e != nil && (latest == nil || e.CreatedAt > latest.CreatedAt) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// LatestMessage returns the message of the most recent event,
+// or an empty string if there are no events.
+func (data *FineTuneEventsData) LatestMessage() string {
+	if latest := data.Latest(); latest != nil {
+		return latest.Message
+	}
+	return ""
+}
+
+// The filterByLevel returns a new list of the events with the level.
+func (data *FineTuneEventsData) filterByLevel(level string) FineTuneEventsData {
+	result := FineTuneEventsData{}
+	for _, e := range *data {
+		if e != nil && e.Level == level {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// The statuses of the fine-tuning job.
+const (
+	FineTuneStatusPending   = "pending"
+	FineTuneStatusRunning   = "running"
+	FineTuneStatusSucceeded = "succeeded"
+	FineTuneStatusFailed    = "failed"
+	FineTuneStatusCancelled = "cancelled"
+)
+
+// FineTuneProgressFunc is called for each new event
+// of the fine-tuning job while waiting for it.
+type FineTuneProgressFunc func(event FineTuneEvent)
+
+// FineTuneFailedError is returned when the waited fine-tuning job fails.
+type FineTuneFailedError struct {
+	FineTune *FineTuneResponse // the last retrieved job
+}
+
+// Error implements the error interface.
+func (e *FineTuneFailedError) Error() string {
+	return fmt.Sprintf("fine-tune %s failed", e.FineTune.ID)
+}
+
+// IsTerminal returns true if the fine-tuning job is finished:
+// it succeeded, failed or was cancelled.
+func (r *FineTuneResponse) IsTerminal() bool {
+	switch r.Status {
+	case FineTuneStatusSucceeded, FineTuneStatusFailed, FineTuneStatusCancelled:
+		return true
+	}
+
+	return false
+}
+
+// Succeeded returns true if the fine-tuning job succeeded.
+func (r *FineTuneResponse) Succeeded() bool {
+	return r.Status == FineTuneStatusSucceeded
+}
+
+// FineTunedModelName returns the name of the fine-tuned model,
+// or ErrModelNotReady if the job isn't completed yet.
+func (r *FineTuneResponse) FineTunedModelName() (string, error) {
+	if r.FineTunedModel == nil {
+		return "", ErrModelNotReady
+	}
+
+	return *r.FineTunedModel, nil
+}
+
+// EventsByLevel returns the events of the job with the level,
+// e.g. "warn" or "error".
+func (r *FineTuneResponse) EventsByLevel(level string) []FineTuneEvent {
+	events := []FineTuneEvent{}
+	for _, e := range r.Events {
+		if e.Level == level {
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
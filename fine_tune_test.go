@@ -0,0 +1,124 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFineTuneRequestError(t *testing.T) {
+	tests := []struct {
+		name string
+		r    FineTuneRequest
+		err  error
+	}{
+		{
+			name: "valid",
+			r:    FineTuneRequest{TrainingFile: "file-abc"},
+		},
+		{
+			name: "valid hyperparameters",
+			r: FineTuneRequest{
+				TrainingFile:                 "file-abc",
+				NEpochs:                      maxFineTuneEpochs,
+				BatchSize:                    8,
+				LearningRateMultiplier:       0.1,
+				PromptLossWeight:             1,
+				ComputeClassificationMetrics: true,
+				ClassificationNClasses:       2,
+				ClassificationPositiveClass:  "yes",
+			},
+		},
+		{
+			name: "no training file",
+			r:    FineTuneRequest{},
+			err:  ErrTrainingFileRequired,
+		},
+		{
+			name: "negative epochs",
+			r:    FineTuneRequest{TrainingFile: "file-abc", NEpochs: -1},
+			err:  ErrInvalidNEpochs,
+		},
+		{
+			name: "too many epochs",
+			r: FineTuneRequest{
+				TrainingFile: "file-abc",
+				NEpochs:      maxFineTuneEpochs + 1,
+			},
+			err: ErrInvalidNEpochs,
+		},
+		{
+			name: "negative batch size",
+			r:    FineTuneRequest{TrainingFile: "file-abc", BatchSize: -1},
+			err:  ErrInvalidBatchSize,
+		},
+		{
+			name: "negative learning rate multiplier",
+			r: FineTuneRequest{
+				TrainingFile:           "file-abc",
+				LearningRateMultiplier: -0.1,
+			},
+			err: ErrInvalidLearningRateMultiplier,
+		},
+		{
+			name: "negative prompt loss weight",
+			r: FineTuneRequest{
+				TrainingFile:     "file-abc",
+				PromptLossWeight: -0.1,
+			},
+			err: ErrInvalidPromptLossWeight,
+		},
+		{
+			name: "prompt loss weight above one",
+			r: FineTuneRequest{
+				TrainingFile:     "file-abc",
+				PromptLossWeight: 1.1,
+			},
+			err: ErrInvalidPromptLossWeight,
+		},
+		{
+			name: "classification without classes",
+			r: FineTuneRequest{
+				TrainingFile:                 "file-abc",
+				ComputeClassificationMetrics: true,
+				ClassificationNClasses:       1,
+			},
+			err: ErrInvalidClassificationNClasses,
+		},
+		{
+			name: "binary classification without positive class",
+			r: FineTuneRequest{
+				TrainingFile:           "file-abc",
+				ClassificationNClasses: 2,
+			},
+			err: ErrPositiveClassRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Error(); !errors.Is(err, tt.err) {
+				t.Errorf("Error() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// TestFineTuneValidation tests that the invalid
+// request isn't sent to the API.
+func TestFineTuneValidation(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id":"ft-abc"}`))
+	})
+
+	_, err := c.FineTune(&FineTuneRequest{})
+	if !errors.Is(err, ErrTrainingFileRequired) {
+		t.Errorf("FineTune() error = %v, want %v", err, ErrTrainingFileRequired)
+	}
+
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+}
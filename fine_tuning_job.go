@@ -0,0 +1,111 @@
+package openai
+
+import "time"
+
+// Check if FineTuningJobRequest implements Requester interface.
+var _ Requester = (*FineTuningJobRequest)(nil)
+
+// FineTuningJobHyperparameters represents the hyperparameters of the
+// fine-tuning job. The zero values mean that the API chooses them
+// automatically.
+type FineTuningJobHyperparameters struct {
+	NEpochs                int     `json:"n_epochs,omitempty"`                 // Number of epochs for training
+	BatchSize              int     `json:"batch_size,omitempty"`               // Batch size for training
+	LearningRateMultiplier float64 `json:"learning_rate_multiplier,omitempty"` // Multiplier for the learning rate
+}
+
+// FineTuningJobRequest represents the request for a fine-tuning job
+// of the /fine_tuning/jobs endpoint.
+type FineTuningJobRequest struct {
+	TrainingFile    string                        `json:"training_file"`             // ID of uploaded file with training data
+	ValidationFile  string                        `json:"validation_file,omitempty"` // ID of uploaded file with validation data
+	Model           string                        `json:"model"`                     // Base model to fine-tune
+	Hyperparameters *FineTuningJobHyperparameters `json:"hyperparameters,omitempty"` // Hyperparameters of the training
+	Suffix          string                        `json:"suffix,omitempty"`          // Suffix for the fine-tuned model name
+	Seed            *int                          `json:"seed,omitempty"`            // Seed for the reproducibility of the job
+	Timeout         time.Duration                 `json:"-"`                         // Timeout of the request, the client's one if zero
+}
+
+// FineTuningJobError represents the error of the failed fine-tuning job.
+type FineTuningJobError struct {
+	Code    string `json:"code"`    // Machine-readable error code
+	Message string `json:"message"` // Human-readable error message
+	Param   string `json:"param"`   // Parameter that was invalid, if any
+}
+
+// FineTuningJobResponse represents the fine-tuning job.
+type FineTuningJobResponse struct {
+	ID              string                       `json:"id"`               // ID of the fine-tuning job
+	Object          string                       `json:"object"`           // Object type (should be "fine_tuning.job")
+	CreatedAt       int64                        `json:"created_at"`       // Timestamp of the job creation
+	FinishedAt      *int64                       `json:"finished_at"`      // Timestamp of the job finish (null if running)
+	Model           string                       `json:"model"`            // Base model being fine-tuned
+	FineTunedModel  *string                      `json:"fine_tuned_model"` // Fine-tuned model name (null if not yet completed)
+	OrganizationID  string                       `json:"organization_id"`  // ID of the organization owning the job
+	Status          string                       `json:"status"`           // Status of the fine-tuning job
+	Hyperparameters FineTuningJobHyperparameters `json:"hyperparameters"`  // Hyperparameters used for fine-tuning
+	TrainingFile    string                       `json:"training_file"`    // ID of the training file
+	ValidationFile  *string                      `json:"validation_file"`  // ID of the validation file (null if not provided)
+	ResultFiles     []string                     `json:"result_files"`     // IDs of the files with the results
+	TrainedTokens   *int                         `json:"trained_tokens"`   // Number of trained tokens (null if running)
+	Error           *FineTuningJobError          `json:"error"`            // Error of the failed job
+	Seed            int                          `json:"seed"`             // Seed used for the job
+}
+
+// FineTuningJobListResponse represents a page of fine-tuning jobs.
+type FineTuningJobListResponse struct {
+	Object  string                   `json:"object"`   // Object type (should be "list")
+	Data    []*FineTuningJobResponse `json:"data"`     // List of fine-tuning jobs
+	HasMore bool                     `json:"has_more"` // Whether there are more jobs
+}
+
+// FineTuningJobEvent represents an event of the fine-tuning job.
+type FineTuningJobEvent struct {
+	ID        string `json:"id"`         // ID of the event
+	Object    string `json:"object"`     // Object type (should be "fine_tuning.job.event")
+	CreatedAt int64  `json:"created_at"` // Timestamp of the event creation
+	Level     string `json:"level"`      // Level of the event (for example, "info")
+	Message   string `json:"message"`    // Message associated with the event
+	Type      string `json:"type"`       // Type of the event (for example, "message", "metrics")
+}
+
+// FineTuningJobEventListResponse represents a page
+// of the fine-tuning job events.
+type FineTuningJobEventListResponse struct {
+	Object  string                `json:"object"`   // Object type (should be "list")
+	Data    []*FineTuningJobEvent `json:"data"`     // List of fine-tuning job events
+	HasMore bool                  `json:"has_more"` // Whether there are more events
+}
+
+// Error returns an error if the request is invalid.
+func (r *FineTuningJobRequest) Error() error {
+	if r.TrainingFile == "" {
+		return ErrFileRequired
+	}
+
+	if r.Model == "" {
+		return ErrModelRequired
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// It is here to satisfy the Requester interface.
+func (r *FineTuningJobRequest) Flush() {}
+
+// The timeout returns the timeout of the request.
+func (r *FineTuningJobRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
+// IsTerminal returns true if the fine-tuning job is finished:
+// it succeeded, failed or was cancelled.
+func (r *FineTuningJobResponse) IsTerminal() bool {
+	switch r.Status {
+	case FineTuneStatusSucceeded, FineTuneStatusFailed, FineTuneStatusCancelled:
+		return true
+	}
+
+	return false
+}
@@ -1,7 +1,10 @@
 module github.com/goloop/openai
 
-go 1.20
+go 1.21
 
-require github.com/goloop/g v1.10.1
+require (
+	github.com/goloop/g v1.10.1
+	golang.org/x/time v0.5.0
+)
 
 require github.com/goloop/trit v1.7.1 // indirect
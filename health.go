@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// healthCheckTimeout is the timeout of the health check
+	// if the context of the caller has no deadline.
+	healthCheckTimeout = 10 * time.Second
+
+	// isHealthyTimeout is the timeout of the IsHealthy method.
+	isHealthyTimeout = 5 * time.Second
+)
+
+// HealthCheckErrorKind is the kind of the health check failure.
+type HealthCheckErrorKind int
+
+// The kinds of the health check failures.
+const (
+	// HealthCheckNetwork means that the API can't be reached:
+	// DNS, connection or timeout errors.
+	HealthCheckNetwork HealthCheckErrorKind = iota
+
+	// HealthCheckAuth means that the API is reachable,
+	// but the credentials don't allow to use it.
+	HealthCheckAuth
+
+	// HealthCheckServer means that the API is reachable,
+	// but responds with an unexpected status code.
+	HealthCheckServer
+)
+
+// HealthCheckError is returned by the HealthCheck method.
+type HealthCheckError struct {
+	Kind HealthCheckErrorKind // kind of the failure
+	Err  error                // the underlying error
+}
+
+// Error implements the error interface.
+func (e *HealthCheckError) Error() string {
+	switch e.Kind {
+	case HealthCheckNetwork:
+		return fmt.Sprintf("health check: API is unreachable: %v", e.Err)
+	case HealthCheckAuth:
+		return fmt.Sprintf("health check: access denied: %v", e.Err)
+	}
+
+	return fmt.Sprintf("health check: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *HealthCheckError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,86 @@
 package openai
 
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// imageModelDallE2 is the DALL-E 2 model, it is used
+	// by default if the model isn't set in the request.
+	imageModelDallE2 = "dall-e-2"
+
+	// imageModelDallE3 is the DALL-E 3 model.
+	imageModelDallE3 = "dall-e-3"
+
+	// maxImagePromptFileSize is the maximum size of the prompt file
+	// of the ImageGenerationFromFile, in bytes.
+	maxImagePromptFileSize = 4096
+)
+
 var (
 	validImageSizes           = []string{"256x256", "512x512", "1024x1024"}
 	validImageResponseFormats = []string{"url", "b64_json"}
+
+	validDallE3ImageSizes = []string{"1024x1024", "1024x1792", "1792x1024"}
+	validDallE3Qualities  = []string{"standard", "hd"}
+	validDallE3Styles     = []string{"vivid", "natural"}
+
+	// The validImageExtensions are the extensions of the image files,
+	// the path with another extension is treated as a directory.
+	validImageExtensions = []string{".png", ".jpg", ".jpeg", ".webp"}
+)
+
+// The PNG signature and the RGBA colour type of the IHDR chunk.
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	pngColorRGBA = byte(6)
 )
+
+// The validatePNG checks the header of the image file: it must be a PNG
+// with the alpha channel (RGBA), and square if the square is true. The
+// header is read with ReadAt, so the offset of the file isn't changed.
+func validatePNG(file *os.File, square bool) error {
+	// The signature is followed by the IHDR chunk: the length and
+	// type (8 bytes), width and height (4 bytes each), bit depth
+	// and colour type (1 byte each).
+	header := make([]byte, 26)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		if err == io.EOF {
+			return ErrImageNotPNG
+		}
+		return err
+	}
+
+	if !bytes.Equal(header[:8], pngSignature) {
+		return ErrImageNotPNG
+	}
+
+	if header[25] != pngColorRGBA {
+		return ErrImageNotRGBA
+	}
+
+	width := binary.BigEndian.Uint32(header[16:20])
+	height := binary.BigEndian.Uint32(header[20:24])
+	if square && width != height {
+		return ErrImageNotSquare
+	}
+
+	return nil
+}
+
+// The imageExtension returns the file extension of the image
+// by its MIME type, ".png" is used for the unknown types.
+func imageExtension(contentType string) string {
+	switch strings.TrimSpace(strings.Split(contentType, ";")[0]) {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	}
+
+	return ".png"
+}
@@ -1,7 +1,11 @@
 package openai
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/goloop/g"
 )
@@ -11,13 +15,16 @@ var _ Requester = (*ImageEditRequest)(nil)
 
 // ImageEditRequest represents a request to the OpenAI Image API.
 type ImageEditRequest struct {
-	Image          *os.File `json:"image"`                     // Base64-encoded PNG file, less than 4MB, and square.
-	Mask           *os.File `json:"mask,omitempty"`            // Optional Base64-encoded PNG mask file.
-	Prompt         string   `json:"prompt"`                    // Text description of the desired image(s).
-	N              int      `json:"n,omitempty"`               // Number of images to generate. Default 1.
-	Size           string   `json:"size,omitempty"`            // Size of the generated images. Default 1024x1024.
-	ResponseFormat string   `json:"response_format,omitempty"` // Format in which the images are returned. Default url.
-	User           string   `json:"user,omitempty"`            // Unique identifier representing the end-user.
+	Image          *os.File      `json:"image"`                     // Base64-encoded PNG file, less than 4MB, and square.
+	Mask           *os.File      `json:"mask,omitempty"`            // Optional Base64-encoded PNG mask file.
+	Prompt         string        `json:"prompt"`                    // Text description of the desired image(s).
+	N              int           `json:"n,omitempty"`               // Number of images to generate. Default 1.
+	Size           string        `json:"size,omitempty"`            // Size of the generated images. Default 1024x1024.
+	ResponseFormat string        `json:"response_format,omitempty"` // Format in which the images are returned. Default url.
+	User           string        `json:"user,omitempty"`            // Unique identifier representing the end-user.
+	Timeout        time.Duration `json:"-"`                         // Timeout of the request, the client's one if zero
+
+	tempFiles []string // temporary files to remove on Flush
 }
 
 type ImageEditData struct {
@@ -38,6 +45,10 @@ type ImageEditResponse struct {
 
 	// Is the number of parallel tasks to use when saving images.
 	parallelTasks int
+
+	// Are the HTTP client and the context to download images.
+	httpClient *http.Client
+	context    context.Context
 }
 
 // OpenImageFile reads an image from a file and assigns the *os.File
@@ -68,11 +79,59 @@ func (r *ImageEditRequest) OpenMaskFile(path string) error {
 	return nil
 }
 
+// SetImageReader writes the image from the reader to a temporary file
+// and assigns it to the Image field of the request. The filename is
+// used to detect the image format, e.g. "image.png". The temporary
+// file is removed on Flush.
+func (r *ImageEditRequest) SetImageReader(
+	reader io.Reader,
+	filename string,
+) error {
+	r.CloseImageFile()
+	file, err := createTempFile(reader, filename)
+	if err != nil {
+		return err
+	}
+
+	r.Image = file
+	r.tempFiles = append(r.tempFiles, file.Name())
+	return nil
+}
+
+// SetMaskReader writes the mask from the reader to a temporary file
+// and assigns it to the Mask field of the request. The temporary
+// file is removed on Flush.
+func (r *ImageEditRequest) SetMaskReader(
+	reader io.Reader,
+	filename string,
+) error {
+	r.CloseMaskFile()
+	file, err := createTempFile(reader, filename)
+	if err != nil {
+		return err
+	}
+
+	r.Mask = file
+	r.tempFiles = append(r.tempFiles, file.Name())
+	return nil
+}
+
 func (r *ImageEditRequest) Error() error {
 	if r.Image == nil {
 		return ErrImageRequired
 	}
 
+	// The image must be a square RGBA PNG, the mask too.
+	if err := validatePNG(r.Image, true); err != nil {
+		return err
+	}
+
+	if r.Mask != nil {
+		if err := validatePNG(r.Mask, false); err != nil {
+			return err
+		}
+	}
+
 	if r.Prompt == "" {
 		return ErrPromptRequired
 	}
@@ -97,15 +156,23 @@ func (r *ImageEditRequest) CloseImageFile() {
 
 // CloseMaskFile closes the Mask file descriptor associated with the request.
 func (r *ImageEditRequest) CloseMaskFile() {
-	if r.Image != nil {
-		r.Image.Close()
+	if r.Mask != nil {
+		r.Mask.Close()
 	}
 }
 
-// Flush closes the files descriptors associated with the request.
+// Flush closes the files descriptors associated with the request
+// and removes the temporary files created by the request.
 func (r *ImageEditRequest) Flush() {
 	r.CloseImageFile()
 	r.CloseMaskFile()
+	removeTempFiles(r.tempFiles)
+	r.tempFiles = nil
+}
+
+// The timeout returns the timeout of the request.
+func (r *ImageEditRequest) timeout() time.Duration {
+	return r.Timeout
 }
 
 func (r *ImageEditResponse) Save(path string) error {
@@ -119,7 +186,13 @@ func (r *ImageEditResponse) Save(path string) error {
 			items[i] = data.URL
 		}
 
-		return saveByURL(path, g.Value(r.parallelTasks, parallelTasks), items)
+		return saveByURLWithContext(
+			r.context,
+			r.httpClient,
+			path,
+			g.Value(r.parallelTasks, parallelTasks),
+			items,
+		)
 	}
 
 	if r.Data[0].Base64 != "" {
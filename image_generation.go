@@ -1,6 +1,14 @@
 package openai
 
-import "github.com/goloop/g"
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/goloop/g"
+)
 
 // Check if ImageGenerationRequest implements Requester interface.
 var _ Requester = (*ImageGenerationRequest)(nil)
@@ -8,6 +16,10 @@ var _ Requester = (*ImageGenerationRequest)(nil)
 // ImageGenerationRequest represents the structure
 // of a request to the OpenAI API.
 type ImageGenerationRequest struct {
+	// Model is the model to use for image generation:
+	// dall-e-2 or dall-e-3. Optional, dall-e-2 by default.
+	Model string `json:"model,omitempty"`
+
 	// Prompt is a text description of the desired image(s).
 	Prompt string `json:"prompt"`
 
@@ -21,8 +33,52 @@ type ImageGenerationRequest struct {
 	// are returned. Optional.
 	ResponseFormat string `json:"response_format,omitempty"`
 
+	// Quality of the generated images: standard or hd.
+	// Only dall-e-3 supports hd. Optional.
+	Quality string `json:"quality,omitempty"`
+
+	// Style of the generated images: vivid or natural.
+	// Only dall-e-3 supports it. Optional.
+	Style string `json:"style,omitempty"`
+
 	// User is a unique identifier representing the end-user. Optional.
 	User string `json:"user,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
+}
+
+// ImageGenerationOption sets a parameter of the image generation
+// request created by the ImageGenerationFromFile.
+type ImageGenerationOption func(*ImageGenerationRequest)
+
+// WithImageN sets the number of images to generate.
+func WithImageN(n int) ImageGenerationOption {
+	return func(r *ImageGenerationRequest) {
+		r.N = n
+	}
+}
+
+// WithImageSize sets the size of the generated images.
+func WithImageSize(size string) ImageGenerationOption {
+	return func(r *ImageGenerationRequest) {
+		r.Size = size
+	}
+}
+
+// WithImageResponseFormat sets the format of the generated images.
+func WithImageResponseFormat(format string) ImageGenerationOption {
+	return func(r *ImageGenerationRequest) {
+		r.ResponseFormat = format
+	}
+}
+
+// WithImageUser sets the ID of the end-user of the image generation.
+func WithImageUser(user string) ImageGenerationOption {
+	return func(r *ImageGenerationRequest) {
+		r.User = user
+	}
 }
 
 // ImageGenerationData represents the structure
@@ -46,6 +102,10 @@ type ImageGenerationResponse struct {
 
 	// Is the number of parallel tasks to use when saving images.
 	parallelTasks int
+
+	// Are the HTTP client and the context to download images.
+	httpClient *http.Client
+	context    context.Context
 }
 
 // Error returns an error if the request is invalid.
@@ -58,8 +118,32 @@ func (r *ImageGenerationRequest) Error() error {
 		return ErrInvalidResponseFormat
 	}
 
-	if !g.In(r.Size, validImageSizes...) {
-		return ErrInvalidSize
+	// The sizes, qualities and styles depend on the model.
+	switch r.Model {
+	case imageModelDallE3:
+		if r.Size != "" && !g.In(r.Size, validDallE3ImageSizes...) {
+			return ErrInvalidSize
+		}
+
+		if r.Quality != "" && !g.In(r.Quality, validDallE3Qualities...) {
+			return ErrInvalidQuality
+		}
+
+		if r.Style != "" && !g.In(r.Style, validDallE3Styles...) {
+			return ErrInvalidStyle
+		}
+	case imageModelDallE2, "":
+		if !g.In(r.Size, validImageSizes...) {
+			return ErrInvalidSize
+		}
+
+		if r.Quality != "" && r.Quality != "standard" {
+			return ErrInvalidQuality
+		}
+
+		if r.Style != "" {
+			return ErrInvalidStyle
+		}
 	}
 
 	return nil
@@ -69,6 +153,11 @@ func (r *ImageGenerationRequest) Error() error {
 // It is here to satisfy the Requester interface.
 func (r *ImageGenerationRequest) Flush() {}
 
+// The timeout returns the timeout of the request.
+func (r *ImageGenerationRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
 func (r *ImageGenerationResponse) Save(path string) error {
 	if len(r.Data) == 0 {
 		return nil
@@ -80,7 +169,13 @@ func (r *ImageGenerationResponse) Save(path string) error {
 			items[i] = data.URL
 		}
 
-		return saveByURL(path, g.Value(r.parallelTasks, parallelTasks), items)
+		return saveByURLWithContext(
+			r.context,
+			r.httpClient,
+			path,
+			g.Value(r.parallelTasks, parallelTasks),
+			items,
+		)
 	}
 
 	if r.Data[0].Base64 != "" {
@@ -94,3 +189,67 @@ func (r *ImageGenerationResponse) Save(path string) error {
 
 	return nil
 }
+
+// URLs returns the URLs of the generated images. The strings
+// are empty if the images were requested in b64_json format.
+func (r *ImageGenerationResponse) URLs() []string {
+	urls := make([]string, len(r.Data))
+	for i, data := range r.Data {
+		urls[i] = data.URL
+	}
+
+	return urls
+}
+
+// Base64Strings returns the base64 encoded images. The strings
+// are empty if the images were requested in url format.
+func (r *ImageGenerationResponse) Base64Strings() []string {
+	items := make([]string, len(r.Data))
+	for i, data := range r.Data {
+		items[i] = data.Base64
+	}
+
+	return items
+}
+
+// Bytes decodes the base64 encoded images in parallel. It returns
+// ErrNoBase64Data if the images were requested in url format.
+func (r *ImageGenerationResponse) Bytes() ([][]byte, error) {
+	var wg sync.WaitGroup
+
+	data := make([][]byte, len(r.Data))
+	errs := make([]error, len(r.Data))
+	sem := make(chan struct{}, g.Value(r.parallelTasks, parallelTasks))
+
+	for i, item := range r.Data {
+		if item.Base64 == "" {
+			return nil, ErrNoBase64Data
+		}
+
+		wg.Add(1)
+		go func(i int, item string) {
+			// Acquire a "token" from the semaphore.
+			sem <- struct{}{}
+
+			// Release the "token" back to the semaphore when done.
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			data[i], errs[i] = base64.StdEncoding.DecodeString(item)
+		}(i, item.Base64)
+	}
+
+	// Wait for all goroutines to finish.
+	wg.Wait()
+
+	// Get the first error from the list.
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
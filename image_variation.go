@@ -1,7 +1,11 @@
 package openai
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/goloop/g"
 )
@@ -16,11 +20,14 @@ var _ Requester = (*ImageVariationRequest)(nil)
 // response_format (the format in which the images are returned, default is url),
 // and user (a unique identifier representing your end-user).
 type ImageVariationRequest struct {
-	Image          *os.File `json:"image"`                     // Base64-encoded PNG file
-	N              int      `json:"n,omitempty"`               // Number of images to generate
-	Size           string   `json:"size,omitempty"`            // Size of the generated images
-	ResponseFormat string   `json:"response_format,omitempty"` // Format of the returned images
-	User           string   `json:"user,omitempty"`            // Unique identifier of the end-user
+	Image          *os.File      `json:"image"`                     // Base64-encoded PNG file
+	N              int           `json:"n,omitempty"`               // Number of images to generate
+	Size           string        `json:"size,omitempty"`            // Size of the generated images
+	ResponseFormat string        `json:"response_format,omitempty"` // Format of the returned images
+	User           string        `json:"user,omitempty"`            // Unique identifier of the end-user
+	Timeout        time.Duration `json:"-"`                         // Timeout of the request, the client's one if zero
+
+	tempFiles []string // temporary files to remove on Flush
 }
 
 type ImageVariationData struct {
@@ -40,6 +47,10 @@ type ImageVariationResponse struct {
 
 	// Is the number of parallel tasks to use when saving images.
 	parallelTasks int
+
+	// Are the HTTP client and the context to download images.
+	httpClient *http.Client
+	context    context.Context
 }
 
 func (r *ImageVariationResponse) Save(path string) error {
@@ -53,7 +64,13 @@ func (r *ImageVariationResponse) Save(path string) error {
 			items[i] = data.URL
 		}
 
-		return saveByURL(path, g.Value(r.parallelTasks, parallelTasks), items)
+		return saveByURLWithContext(
+			r.context,
+			r.httpClient,
+			path,
+			g.Value(r.parallelTasks, parallelTasks),
+			items,
+		)
 	}
 
 	if r.Data[0].Base64 != "" {
@@ -85,6 +102,25 @@ func (r *ImageVariationRequest) OpenImageFile(path string) error {
 	return nil
 }
 
+// SetImageReader writes the image from the reader to a temporary file
+// and assigns it to the Image field of the request. The filename is
+// used to detect the image format, e.g. "image.png". The temporary
+// file is removed on Flush.
+func (r *ImageVariationRequest) SetImageReader(
+	reader io.Reader,
+	filename string,
+) error {
+	r.CloseImageFile()
+	file, err := createTempFile(reader, filename)
+	if err != nil {
+		return err
+	}
+
+	r.Image = file
+	r.tempFiles = append(r.tempFiles, file.Name())
+	return nil
+}
+
 func (r *ImageVariationRequest) Error() error {
 	if r.Image == nil {
 		return ErrImageRequired
@@ -108,7 +144,15 @@ func (r *ImageVariationRequest) CloseImageFile() {
 	}
 }
 
-// Flush closes the files descriptors associated with the request.
+// Flush closes the files descriptors associated with the request
+// and removes the temporary files created by the request.
 func (r *ImageVariationRequest) Flush() {
 	r.CloseImageFile()
+	removeTempFiles(r.tempFiles)
+	r.tempFiles = nil
+}
+
+// The timeout returns the timeout of the request.
+func (r *ImageVariationRequest) timeout() time.Duration {
+	return r.Timeout
 }
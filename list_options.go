@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// ListOptions represents the pagination parameters
+// of the list endpoints of the OpenAI API.
+type ListOptions struct {
+	// The number of objects to return, the API
+	// uses its own default value if it's zero.
+	Limit int
+
+	// The sort order by the creation time of the objects:
+	// "asc" for ascending and "desc" for descending order.
+	Order string
+
+	// The cursor for pagination, the ID of the object
+	// after which the list should start.
+	After string
+
+	// The cursor for pagination, the ID of the object
+	// before which the list should end.
+	Before string
+}
+
+// Values returns the non-zero options as URL query parameters.
+func (o *ListOptions) Values() url.Values {
+	values := url.Values{}
+	for k, v := range o.query() {
+		if v != "" {
+			values.Set(k, v)
+		}
+	}
+
+	return values
+}
+
+// The query returns the options as query parameters, the zero
+// values are kept in the map and omitted when the URL is built.
+func (o *ListOptions) query() map[string]string {
+	if o == nil {
+		return nil
+	}
+
+	query := map[string]string{
+		"order":  o.Order,
+		"after":  o.After,
+		"before": o.Before,
+	}
+
+	if o.Limit > 0 {
+		query["limit"] = strconv.Itoa(o.Limit)
+	}
+
+	return query
+}
@@ -0,0 +1,184 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/goloop/g"
+)
+
+// Check if MessageCreateRequest implements Requester interface.
+var _ Requester = (*MessageCreateRequest)(nil)
+
+// Attachment represents a file attached to the message
+// and the tools it should be added to.
+type Attachment struct {
+	// The ID of the file to attach to the message.
+	FileID string `json:"file_id"`
+
+	// The tools to add this file to.
+	Tools []Tool `json:"tools,omitempty"`
+}
+
+// MessageCreateRequest represents a request to create
+// a message in the thread with the OpenAI Messages API.
+type MessageCreateRequest struct {
+	// The role of the entity that is creating the message:
+	// "user" or "assistant". This is required.
+	Role string `json:"role"`
+
+	// The content of the message. This is required.
+	Content string `json:"content"`
+
+	// A list of files attached to the message.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// Set of key-value pairs that can be attached to the message.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// MessageModifyRequest represents a request to modify
+// a message with the OpenAI Messages API.
+type MessageModifyRequest struct {
+	// Set of key-value pairs that can be attached to the message.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// MessageText is the text content of the message.
+type MessageText struct {
+	// The data that makes up the text.
+	Value string `json:"value"`
+
+	// The annotations of the text, like file citations.
+	Annotations []json.RawMessage `json:"annotations,omitempty"`
+}
+
+// MessageImageFile is the image file content of the message.
+type MessageImageFile struct {
+	// The ID of the image file.
+	FileID string `json:"file_id"`
+
+	// The detail level of the image: "auto", "low" or "high".
+	Detail string `json:"detail,omitempty"`
+}
+
+// MessageImageURL is the image URL content of the message.
+type MessageImageURL struct {
+	// The URL of the image.
+	URL string `json:"url"`
+
+	// The detail level of the image: "auto", "low" or "high".
+	Detail string `json:"detail,omitempty"`
+}
+
+// MessageContent is a part of the message content. It is a union type,
+// the Type field defines which of the other fields is set: "text",
+// "image_file" or "image_url".
+type MessageContent struct {
+	// The type of the content part.
+	Type string `json:"type"`
+
+	// The text content, set if the Type is "text".
+	Text *MessageText `json:"text,omitempty"`
+
+	// The image file content, set if the Type is "image_file".
+	ImageFile *MessageImageFile `json:"image_file,omitempty"`
+
+	// The image URL content, set if the Type is "image_url".
+	ImageURL *MessageImageURL `json:"image_url,omitempty"`
+}
+
+// MessageResponse represents a message object
+// returned by the OpenAI Messages API.
+type MessageResponse struct {
+	// The identifier of the message.
+	ID string `json:"id"`
+
+	// The object type, which is always "thread.message".
+	Object string `json:"object"`
+
+	// The Unix timestamp (in seconds) when the message was created.
+	CreatedAt int64 `json:"created_at"`
+
+	// The ID of the thread the message belongs to.
+	ThreadID string `json:"thread_id"`
+
+	// The entity that produced the message: "user" or "assistant".
+	Role string `json:"role"`
+
+	// The content of the message.
+	Content []MessageContent `json:"content"`
+
+	// The ID of the assistant that authored the message, if applicable.
+	AssistantID string `json:"assistant_id"`
+
+	// The ID of the run associated with the creation
+	// of the message, if applicable.
+	RunID string `json:"run_id"`
+
+	// A list of files attached to the message.
+	Attachments []Attachment `json:"attachments"`
+
+	// Set of key-value pairs attached to the message.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// MessagesData represents a list of messages.
+type MessagesData []*MessageResponse
+
+// MessageListResponse represents the response from
+// the OpenAI Messages API with a list of messages.
+type MessageListResponse struct {
+	// The object type, which is always "list".
+	Object string `json:"object"`
+
+	// A list of messages.
+	Data MessagesData `json:"data"`
+
+	// The ID of the first message in the list.
+	FirstID string `json:"first_id"`
+
+	// The ID of the last message in the list,
+	// it can be used as a cursor for the next page.
+	LastID string `json:"last_id"`
+
+	// Whether there are more messages to fetch.
+	HasMore bool `json:"has_more"`
+}
+
+// Error returns an error if the request is invalid.
+func (r *MessageCreateRequest) Error() error {
+	if !g.In(r.Role, availableThreadRoleList...) {
+		return ErrInvalidRole
+	}
+
+	if r.Content == "" {
+		return ErrMessageRequired
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *MessageCreateRequest) Flush() {
+}
+
+// Text returns the text parts of the message content
+// joined with a newline.
+func (r *MessageResponse) Text() string {
+	var sb strings.Builder
+
+	for _, content := range r.Content {
+		if content.Text == nil {
+			continue
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.TrimSpace(content.Text.Value))
+	}
+
+	return sb.String()
+}
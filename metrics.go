@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MetricsCollector records the metrics of the API calls. It is
+// implemented by an adapter of the metrics library, e.g. Prometheus,
+// so the package doesn't depend on the metrics modules:
+//
+//	type promCollector struct {
+//	    requests *prometheus.CounterVec   // openai_requests_total
+//	    duration *prometheus.HistogramVec // openai_request_duration_seconds
+//	    tokens   *prometheus.CounterVec   // openai_tokens_used_total
+//	    reg      prometheus.Registerer
+//	}
+//
+//	func (p *promCollector) IncRequests(endpoint, model, status string) {
+//	    p.requests.WithLabelValues(endpoint, model, status).Inc()
+//	}
+//
+//	// ObserveDuration, AddTokens ...
+//
+//	func (p *promCollector) Close() error {
+//	    p.reg.Unregister(p.requests)
+//	    p.reg.Unregister(p.duration)
+//	    p.reg.Unregister(p.tokens)
+//	    return nil
+//	}
+//
+// If the collector implements io.Closer, it is closed
+// by the Close method of the MetricsMiddleware.
+type MetricsCollector interface {
+	// IncRequests counts the request, the status is the HTTP
+	// status code, or "error" if no response was received.
+	IncRequests(endpoint, model, status string)
+
+	// ObserveDuration records the duration of the request.
+	ObserveDuration(endpoint, model string, seconds float64)
+
+	// AddTokens counts the tokens used by the request,
+	// the tokenType is "prompt" or "completion".
+	AddTokens(endpoint, model, tokenType string, n int)
+}
+
+// MetricsMiddleware updates the metrics of the collector from each
+// request: the number of requests, their duration and the token usage.
+//
+// Example usage:
+//
+//	metrics := openai.NewMetricsMiddleware(collector)
+//	defer metrics.Close()
+//
+//	client.Use(metrics.Middleware())
+type MetricsMiddleware struct {
+	collector MetricsCollector
+}
+
+// NewMetricsMiddleware creates the middleware
+// that updates the metrics of the collector.
+func NewMetricsMiddleware(collector MetricsCollector) *MetricsMiddleware {
+	return &MetricsMiddleware{collector: collector}
+}
+
+// Middleware returns the middleware to register on the client.
+func (m *MetricsMiddleware) Middleware() Middleware {
+	return m.roundTrip
+}
+
+// Close closes the collector if it implements io.Closer,
+// e.g. to unregister the collectors from the registry.
+func (m *MetricsMiddleware) Close() error {
+	if closer, ok := m.collector.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// The roundTrip sends the request and updates the metrics.
+func (m *MetricsMiddleware) roundTrip(
+	req *http.Request,
+	next http.RoundTripper,
+) (*http.Response, error) {
+	endpoint := operationName(req.URL.Path)
+	model := requestModel(req)
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	m.collector.ObserveDuration(endpoint, model, time.Since(start).Seconds())
+
+	if err != nil {
+		m.collector.IncRequests(endpoint, model, "error")
+		return resp, err
+	}
+
+	m.collector.IncRequests(endpoint, model, strconv.Itoa(resp.StatusCode))
+
+	// The token usage is read from the buffered JSON body, the streams,
+	// e.g. the audio and the file contents, are passed through as is.
+	if !isJSON(resp.Header) || resp.Body == nil {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+
+	scanUsage(body, func(tokenType string, n int) {
+		m.collector.AddTokens(endpoint, model, tokenType, n)
+	})
+
+	return resp, nil
+}
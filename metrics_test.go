@@ -0,0 +1,136 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// The testCollector is the MetricsCollector
+// that counts the tokens by their type.
+type testCollector struct {
+	endpoints []string
+	tokens    map[string]int
+}
+
+// IncRequests records the endpoint of the request.
+func (c *testCollector) IncRequests(endpoint, model, status string) {
+	c.endpoints = append(c.endpoints, endpoint)
+}
+
+// ObserveDuration does nothing.
+func (c *testCollector) ObserveDuration(endpoint, model string, seconds float64) {}
+
+// AddTokens counts the tokens of the type.
+func (c *testCollector) AddTokens(endpoint, model, tokenType string, n int) {
+	c.tokens[tokenType] += n
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v1/chat/completions", "chat/completions"},
+		{"/v1/files/file-abc/content", "files/{id}/content"},
+		{"/v1/models/gpt-4", "models/{id}"},
+		{"/v1/threads/thread_abc/runs/run_abc", "threads/{id}/runs/{id}"},
+		{
+			"/v1/threads/thread_abc/runs/run_abc/submit_tool_outputs",
+			"threads/{id}/runs/{id}/submit_tool_outputs",
+		},
+		{"/v1/fine_tuning/jobs/ftjob-abc/events", "fine_tuning/jobs/{id}/events"},
+		{
+			"/openai/deployments/my-gpt/chat/completions",
+			"openai/deployments/{id}/chat/completions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := operationName(tt.path); got != tt.want {
+				t.Errorf("operationName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		wantRead    bool
+		wantTokens  map[string]int
+	}{
+		{
+			name:        "json response",
+			path:        "/v1/chat/completions",
+			contentType: "application/json",
+			body:        `{"usage":{"prompt_tokens":3,"completion_tokens":5}}`,
+			wantRead:    true,
+			wantTokens:  map[string]int{"prompt": 3, "completion": 5},
+		},
+		{
+			name:        "file content",
+			path:        "/v1/files/file-abc/content",
+			contentType: "application/octet-stream",
+			body:        `{"usage":{"prompt_tokens":3}}`,
+			wantTokens:  map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &testCollector{tokens: map[string]int{}}
+			body := &readFlag{Reader: strings.NewReader(tt.body)}
+			next := roundTripFunc(func(*http.Request) (*http.Response, error) {
+				header := http.Header{}
+				header.Set("Content-Type", tt.contentType)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     header,
+					Body:       body,
+				}, nil
+			})
+
+			req, _ := http.NewRequest(
+				http.MethodGet,
+				"https://api.openai.com"+tt.path,
+				nil,
+			)
+
+			mw := NewMetricsMiddleware(collector).Middleware()
+			resp, err := mw(req, next)
+			if err != nil {
+				t.Fatalf("middleware error = %v", err)
+			}
+
+			if body.read != tt.wantRead {
+				t.Errorf("body read = %v, want %v", body.read, tt.wantRead)
+			}
+
+			if got, _ := io.ReadAll(resp.Body); string(got) != tt.body {
+				t.Errorf("response body = %q, want %q", got, tt.body)
+			}
+
+			for tokenType, n := range tt.wantTokens {
+				if collector.tokens[tokenType] != n {
+					t.Errorf("%s tokens = %d, want %d",
+						tokenType, collector.tokens[tokenType], n)
+				}
+			}
+
+			if len(collector.tokens) != len(tt.wantTokens) {
+				t.Errorf("tokens = %v, want %v", collector.tokens, tt.wantTokens)
+			}
+
+			want := operationName(tt.path)
+			if len(collector.endpoints) != 1 || collector.endpoints[0] != want {
+				t.Errorf("endpoints = %v, want [%s]", collector.endpoints, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package openai
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The usageRegexps find the token counts in the JSON response body
+// without unmarshalling it, so the schema of the response isn't needed.
+var (
+	promptTokensRegexp     = regexp.MustCompile(`"prompt_tokens"\s*:\s*(\d+)`)
+	completionTokensRegexp = regexp.MustCompile(`"completion_tokens"\s*:\s*(\d+)`)
+)
+
+// Check if middlewareTransport implements http.RoundTripper interface.
+var _ http.RoundTripper = (*middlewareTransport)(nil)
+
+// Middleware intercepts the HTTP requests sent by the client. It can
+// inspect or modify the request, pass it to the next round tripper
+// and inspect or modify the response.
+//
+// Example usage:
+//
+//	client.Use(func(
+//	    req *http.Request,
+//	    next http.RoundTripper,
+//	) (*http.Response, error) {
+//	    req.Header.Set("X-Request-Source", "my-app")
+//	    return next.RoundTrip(req)
+//	})
+type Middleware func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// The middlewareTransport is a round tripper
+// that passes the request through the middleware.
+type middlewareTransport struct {
+	middleware Middleware
+	next       http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.middleware(req, t.next)
+}
+
+// The chainMiddlewares wraps the transport into the middlewares.
+// The first middleware is the outermost one, i.e. it gets the
+// request first and the response last.
+func chainMiddlewares(
+	transport http.RoundTripper,
+	middlewares ...Middleware,
+) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = &middlewareTransport{
+			middleware: middlewares[i],
+			next:       transport,
+		}
+	}
+
+	return transport
+}
+
+// LoggingMiddleware returns a middleware that logs the method, URL,
+// status code and latency of each request. If the logger is nil,
+// the standard logger is used.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(
+		req *http.Request,
+		next http.RoundTripper,
+	) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		latency := time.Since(start)
+
+		if err != nil {
+			logger.Printf(
+				"openai: %s %s failed in %s: %v",
+				req.Method, req.URL, latency, err,
+			)
+			return resp, err
+		}
+
+		logger.Printf(
+			"openai: %s %s %d in %s",
+			req.Method, req.URL, resp.StatusCode, latency,
+		)
+		return resp, nil
+	}
+}
+
+// NewSlogMiddleware returns a middleware that logs each request with
+// the structured logger at the level. The attributes are the method, url,
+// status_code, latency_ms, request_id, and the prompt_tokens and
+// completion_tokens found in the JSON response body. The failed requests
+// are logged at the error level with the error attribute.
+//
+// If the level is slog.LevelDebug, the request and response bodies are
+// logged as well, with the API key redacted. Only the JSON responses are
+// read by the middleware, the streams and the file contents aren't. If
+// the logger is nil, the default logger is used.
+func NewSlogMiddleware(logger *slog.Logger, level slog.Level) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(
+		req *http.Request,
+		next http.RoundTripper,
+	) (*http.Response, error) {
+		ctx := req.Context()
+		if !logger.Enabled(ctx, level) && !logger.Enabled(ctx, slog.LevelError) {
+			return next.RoundTrip(req)
+		}
+
+		debug := level <= slog.LevelDebug
+		apiKeys := []string{
+			strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "),
+			req.Header.Get("Api-Key"), // Azure OpenAI
+		}
+
+		var reqBody []byte
+		if debug && req.GetBody != nil && isJSON(req.Header) {
+			if body, err := req.GetBody(); err == nil {
+				reqBody, _ = io.ReadAll(body)
+				body.Close()
+			}
+		}
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Int64("latency_ms", time.Since(start).Milliseconds()),
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "openai: request failed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs,
+			slog.Int("status_code", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get("X-Request-Id")),
+		)
+
+		// Only the JSON body is read, and it is replaced with the read
+		// copy for the client. The streams, e.g. the audio and the file
+		// contents, are passed through as is.
+		if isJSON(resp.Header) && resp.Body != nil {
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			if err != nil {
+				return resp, err
+			}
+
+			attrs = append(attrs, usageAttrs(respBody)...)
+			if debug {
+				attrs = append(attrs,
+					slog.String("request_body", redact(reqBody, apiKeys...)),
+					slog.String("response_body", redact(respBody, apiKeys...)),
+				)
+			}
+		}
+
+		logger.LogAttrs(ctx, level, "openai: request", attrs...)
+		return resp, nil
+	}
+}
+
+// The scanUsage calls the fn with each token count found in the
+// JSON body, the tokenType is "prompt" or "completion".
+func scanUsage(body []byte, fn func(tokenType string, n int)) {
+	for _, usage := range []struct {
+		tokenType string
+		re        *regexp.Regexp
+	}{
+		{"prompt", promptTokensRegexp},
+		{"completion", completionTokensRegexp},
+	} {
+		if m := usage.re.FindSubmatch(body); m != nil {
+			n, _ := strconv.Atoi(string(m[1]))
+			fn(usage.tokenType, n)
+		}
+	}
+}
+
+// The usageAttrs returns the token counts found in the body.
+func usageAttrs(body []byte) []slog.Attr {
+	attrs := []slog.Attr{}
+	scanUsage(body, func(tokenType string, n int) {
+		attrs = append(attrs, slog.Int(tokenType+"_tokens", n))
+	})
+
+	return attrs
+}
+
+// The isJSON returns true if the content type of the header is JSON.
+func isJSON(header http.Header) bool {
+	return strings.HasPrefix(header.Get("Content-Type"), "application/json")
+}
+
+// The redact returns the body as a string with the API keys redacted.
+func redact(body []byte, apiKeys ...string) string {
+	s := string(body)
+	for _, apiKey := range apiKeys {
+		if apiKey != "" {
+			s = strings.ReplaceAll(s, apiKey, "[REDACTED]")
+		}
+	}
+
+	return s
+}
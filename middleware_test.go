@@ -0,0 +1,135 @@
+package openai
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// The roundTripFunc is the http.RoundTripper of the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// The readFlag is the body that remembers if it was read.
+type readFlag struct {
+	io.Reader
+	read bool
+}
+
+// Read reads the body and sets the flag.
+func (r *readFlag) Read(b []byte) (int, error) {
+	r.read = true
+	return r.Reader.Read(b)
+}
+
+// Close does nothing.
+func (r *readFlag) Close() error {
+	return nil
+}
+
+func TestNewSlogMiddleware(t *testing.T) {
+	const secret = "sk-secret"
+
+	tests := []struct {
+		name        string
+		header      string
+		value       string
+		contentType string
+		respBody    string
+		wantRead    bool
+		want        []string
+		notWant     []string
+	}{
+		{
+			name:        "json response",
+			header:      "Authorization",
+			value:       "Bearer " + secret,
+			contentType: "application/json",
+			respBody:    `{"key":"` + secret + `","usage":{"prompt_tokens":3}}`,
+			wantRead:    true,
+			want:        []string{`"prompt_tokens":3`, "[REDACTED]"},
+			notWant:     []string{secret},
+		},
+		{
+			name:        "azure api key",
+			header:      "Api-Key",
+			value:       secret,
+			contentType: "application/json",
+			respBody:    `{"key":"` + secret + `"}`,
+			wantRead:    true,
+			want:        []string{"[REDACTED]"},
+			notWant:     []string{secret},
+		},
+		{
+			name:        "audio stream",
+			header:      "Authorization",
+			value:       "Bearer " + secret,
+			contentType: "audio/mpeg",
+			respBody:    "audio",
+			notWant:     []string{"response_body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(
+				&logs,
+				&slog.HandlerOptions{Level: slog.LevelDebug},
+			))
+
+			body := &readFlag{Reader: strings.NewReader(tt.respBody)}
+			next := roundTripFunc(func(*http.Request) (*http.Response, error) {
+				header := http.Header{}
+				header.Set("Content-Type", tt.contentType)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     header,
+					Body:       body,
+				}, nil
+			})
+
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"https://api.openai.com/v1/chat/completions",
+				strings.NewReader(`{"key":"`+secret+`"}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(tt.header, tt.value)
+
+			mw := NewSlogMiddleware(logger, slog.LevelDebug)
+			resp, err := mw(req, next)
+			if err != nil {
+				t.Fatalf("middleware error = %v", err)
+			}
+
+			if body.read != tt.wantRead {
+				t.Errorf("body read = %v, want %v", body.read, tt.wantRead)
+			}
+
+			got, _ := io.ReadAll(resp.Body)
+			if string(got) != tt.respBody {
+				t.Errorf("response body = %q, want %q", got, tt.respBody)
+			}
+
+			for _, s := range tt.want {
+				if !strings.Contains(logs.String(), s) {
+					t.Errorf("log %s doesn't contain %q", logs.String(), s)
+				}
+			}
+
+			for _, s := range tt.notWant {
+				if strings.Contains(logs.String(), s) {
+					t.Errorf("log %s contains %q", logs.String(), s)
+				}
+			}
+		})
+	}
+}
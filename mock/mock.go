@@ -0,0 +1,235 @@
+// Package mock provides a fake OpenAI API client for unit testing
+// the code that uses the openai package without hitting the network.
+//
+// The mock client intercepts the HTTP requests at the transport level,
+// so all openai.Client methods work unchanged:
+//
+//	m := mock.NewClient()
+//	m.On(http.MethodPost, mock.Path("/chat/completions")).
+//	    Return(http.StatusOK, []byte(`{"choices": [...]}`))
+//
+//	client := openai.New(m.Config())
+//	resp, err := client.ChatCompletion(r)
+package mock
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/goloop/openai"
+)
+
+// Check if Client implements openai.Clienter interface.
+var _ openai.Clienter = (*Client)(nil)
+
+// Check if Transport implements http.RoundTripper interface.
+var _ http.RoundTripper = (*Transport)(nil)
+
+const (
+	// apiKey is the fake API key of the mock client.
+	apiKey = "sk-mock"
+
+	// apiBaseURL is the fake base URL of the mock client.
+	apiBaseURL = "https://api.openai.mock/v1"
+)
+
+// Call is the expected request and the response returned for it.
+type Call struct {
+	method  string
+	matcher func(*http.Request) bool
+
+	statusCode int
+	body       []byte
+	header     http.Header
+	err        error
+}
+
+// Return sets the status code and the body of the response.
+// The response has the application/json content type.
+func (c *Call) Return(statusCode int, body []byte) *Call {
+	c.statusCode = statusCode
+	c.body = body
+	c.header = http.Header{"Content-Type": []string{"application/json"}}
+	c.err = nil
+	return c
+}
+
+// ReturnError sets the error returned instead of the response,
+// e.g. to emulate a network failure.
+func (c *Call) ReturnError(err error) *Call {
+	c.err = err
+	return c
+}
+
+// Path returns the matcher of the requests whose URL path
+// ends with the given path, e.g. "/chat/completions".
+func Path(path string) func(*http.Request) bool {
+	return func(req *http.Request) bool {
+		return strings.HasSuffix(req.URL.Path, path)
+	}
+}
+
+// Transport is the http.RoundTripper that returns the pre-configured
+// responses instead of sending the requests, and records the requests.
+type Transport struct {
+	mu       sync.Mutex
+	calls    []*Call
+	requests []*http.Request
+}
+
+// On registers the expected request. The method is the HTTP method of
+// the request, an empty method matches any one. The matcher can check
+// the other parameters of the request, nil matcher matches any request.
+// If several calls match the request, the first registered one is used.
+func (t *Transport) On(method string, matcher func(*http.Request) bool) *Call {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	call := &Call{method: method, matcher: matcher}
+	call.Return(http.StatusOK, []byte("{}"))
+	t.calls = append(t.calls, call)
+	return call
+}
+
+// Requests returns the requests received by the transport.
+// The bodies of the requests can be read again.
+func (t *Transport) Requests() []*http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]*http.Request(nil), t.requests...)
+}
+
+// Reset removes all the registered calls and the received requests.
+func (t *Transport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.calls, t.requests = nil, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	// The rewind lets the matchers and the
+	// test code read the body repeatedly.
+	rewind := func() {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rewind()
+	t.requests = append(t.requests, req)
+
+	for _, call := range t.calls {
+		if call.method != "" && call.method != req.Method {
+			continue
+		}
+
+		if call.matcher != nil {
+			ok := call.matcher(req)
+			rewind()
+			if !ok {
+				continue
+			}
+		}
+
+		if call.err != nil {
+			return nil, call.err
+		}
+
+		return &http.Response{
+			Status:        http.StatusText(call.statusCode),
+			StatusCode:    call.statusCode,
+			Header:        call.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(call.body)),
+			ContentLength: int64(len(call.body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("mock: unexpected request %s %s", req.Method, req.URL)
+}
+
+// Client is the fake OpenAI API client. It implements the
+// openai.Clienter interface and sends all requests to the
+// mock transport.
+type Client struct {
+	*Transport
+
+	httpClient *http.Client
+}
+
+// NewClient creates a new mock client without registered calls.
+func NewClient() *Client {
+	transport := &Transport{}
+	return &Client{
+		Transport:  transport,
+		httpClient: &http.Client{Transport: transport},
+	}
+}
+
+// Config returns the configuration of the openai.Client
+// that sends all requests to the mock transport.
+func (c *Client) Config() openai.Config {
+	return openai.Config{
+		APIKey:     apiKey,
+		APIBaseURL: apiBaseURL,
+		HTTPClient: c.httpClient,
+	}
+}
+
+// APIKey returns the fake API key.
+func (c *Client) APIKey() string {
+	return apiKey
+}
+
+// OrgID returns an empty organization ID.
+func (c *Client) OrgID() string {
+	return ""
+}
+
+// Endpoint returns the URL of the fake API, the path elements
+// are joined like in the Endpoint method of the openai.Client.
+func (c *Client) Endpoint(p ...string) string {
+	u, _ := url.Parse(apiBaseURL)
+	u.Path = path.Join(u.Path, path.Join(p...))
+	return u.String()
+}
+
+// ParallelTasks returns the number of parallel requests.
+func (c *Client) ParallelTasks() int {
+	return 1
+}
+
+// Context returns the background context.
+func (c *Client) Context() context.Context {
+	return context.Background()
+}
+
+// HTTPHeaders returns no additional HTTP headers.
+func (c *Client) HTTPHeaders() http.Header {
+	return http.Header{}
+}
+
+// HTTPClient returns the HTTP client that uses the mock transport.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
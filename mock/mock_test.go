@@ -0,0 +1,152 @@
+package mock
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/goloop/openai"
+)
+
+func TestClient(t *testing.T) {
+	errNetwork := errors.New("network is down")
+
+	tests := []struct {
+		name  string
+		setup func(m *Client)
+		want  string
+		err   error
+	}{
+		{
+			name: "return",
+			setup: func(m *Client) {
+				m.On(http.MethodPost, Path("/chat/completions")).
+					Return(http.StatusOK, []byte(`{"choices":[{"message":`+
+						`{"role":"assistant","content":"Hi"}}]}`))
+			},
+			want: "Hi",
+		},
+		{
+			name: "return error",
+			setup: func(m *Client) {
+				m.On("", nil).ReturnError(errNetwork)
+			},
+			err: errNetwork,
+		},
+		{
+			name: "first matching call",
+			setup: func(m *Client) {
+				m.On(http.MethodGet, nil).
+					Return(http.StatusOK, []byte(`{}`))
+				m.On("", func(req *http.Request) bool {
+					body, _ := io.ReadAll(req.Body)
+					return strings.Contains(string(body), `"gpt-4o"`)
+				}).Return(http.StatusOK, []byte(`{"choices":[{"message":`+
+					`{"role":"assistant","content":"Matched"}}]}`))
+				m.On("", nil).ReturnError(errNetwork)
+			},
+			want: "Matched",
+		},
+		{
+			name:  "unexpected request",
+			setup: func(m *Client) {},
+			err:   errors.New("mock: unexpected request"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewClient()
+			tt.setup(m)
+
+			client := openai.New(m.Config())
+			resp, err := client.ChatCompletion(&openai.ChatCompletionRequest{
+				Model: "gpt-4o",
+				Messages: []openai.ChatCompletionMessage{
+					{Role: "user", Content: "Hello"},
+				},
+			})
+
+			switch {
+			case tt.err == nil && err != nil:
+				t.Fatalf("ChatCompletion() error = %v", err)
+			case tt.err != nil && err == nil:
+				t.Fatalf("ChatCompletion() error = nil, want %v", tt.err)
+			case tt.err != nil && !errors.Is(err, tt.err) &&
+				!strings.Contains(err.Error(), tt.err.Error()):
+				t.Fatalf("ChatCompletion() error = %v, want %v", err, tt.err)
+			}
+
+			if tt.err == nil && resp.FirstText() != tt.want {
+				t.Errorf("FirstText() = %q, want %q", resp.FirstText(), tt.want)
+			}
+
+			// The request is recorded and its body can be read again.
+			requests := m.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("Requests() = %d, want 1", len(requests))
+			}
+
+			body, err := io.ReadAll(requests[0].Body)
+			if err != nil || !strings.Contains(string(body), `"Hello"`) {
+				t.Errorf("request body = %q, %v", body, err)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	m := NewClient()
+	m.On("", nil)
+
+	client := openai.New(m.Config())
+	if _, err := client.Models(); err != nil {
+		t.Fatalf("Models() error = %v", err)
+	}
+
+	m.Reset()
+	if len(m.Requests()) != 0 {
+		t.Errorf("Requests() = %d, want 0", len(m.Requests()))
+	}
+
+	if _, err := client.Models(); err == nil {
+		t.Error("Models() error = nil, want an unexpected request")
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	m := NewClient()
+	client := openai.New(m.Config())
+
+	tests := []struct {
+		name string
+		p    []string
+		want string
+	}{
+		{
+			name: "one segment",
+			p:    []string{"/models"},
+			want: apiBaseURL + "/models",
+		},
+		{
+			name: "several segments",
+			p:    []string{"/files", "f1"},
+			want: apiBaseURL + "/files/f1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Endpoint(tt.p...); got != tt.want {
+				t.Errorf("Endpoint() = %q, want %q", got, tt.want)
+			}
+
+			// The mock joins the path like the real client.
+			if got := client.Endpoint(tt.p...); got != tt.want {
+				t.Errorf("openai Endpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package openai_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/goloop/openai"
+	"github.com/goloop/openai/mock"
+)
+
+// TestModelsWithMock tests the parallel fetching of the models
+// through the mock client, without a real API key.
+func TestModelsWithMock(t *testing.T) {
+	m := mock.NewClient()
+	m.On(http.MethodGet, mock.Path("/models/gpt-4o")).
+		Return(http.StatusOK, []byte(`{"id":"gpt-4o","object":"model"}`))
+	m.On(http.MethodGet, mock.Path("/models/tts-1")).
+		Return(http.StatusOK, []byte(`{"id":"tts-1","object":"model"}`))
+
+	client := openai.New(m.Config())
+	data, err := client.Models("gpt-4o", "tts-1")
+	if err != nil {
+		t.Fatalf("Models() error = %v", err)
+	}
+
+	if got := strings.Join(data.Names(), ","); got != "gpt-4o,tts-1" {
+		t.Errorf("Names() = %q, want %q", got, "gpt-4o,tts-1")
+	}
+
+	if n := len(m.Requests()); n != 2 {
+		t.Errorf("Requests() = %d, want 2", n)
+	}
+}
@@ -1,5 +1,10 @@
 package openai
 
+import (
+	"sort"
+	"time"
+)
+
 // ModelDeleteResponse represents the response from the delete model endpoint.
 type ModelDeleteResponse struct {
 	ID      string `json:"id"`
@@ -100,9 +105,92 @@ func (data *ModelsData) Len() int {
 
 // Names returns a list of the names of the models.
 func (data *ModelsData) Names() []string {
-	names := make([]string, data.Len())
-	for i, m := range *data {
-		names[i] = m.Name()
+	names := make([]string, 0, data.Len())
+	for _, m := range *data {
+		if m != nil {
+			names = append(names, m.Name())
+		}
 	}
 	return names
 }
+
+// FilterByOwner returns a new list of the models owned by the owner.
+func (data *ModelsData) FilterByOwner(owner string) ModelsData {
+	return data.filter(func(m *ModelDetails) bool {
+		return m.OwnedBy == owner
+	})
+}
+
+// FilterByCreatedAfter returns a new list
+// of the models created after the time.
+func (data *ModelsData) FilterByCreatedAfter(t time.Time) ModelsData {
+	return data.filter(func(m *ModelDetails) bool {
+		return time.Unix(m.Created, 0).After(t)
+	})
+}
+
+// FilterByAllowFineTuning returns a new list of the models
+// that have a permission that allows fine-tuning.
+func (data *ModelsData) FilterByAllowFineTuning() ModelsData {
+	return data.filter(func(m *ModelDetails) bool {
+		for _, p := range m.Permission {
+			if p.AllowFineTuning {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FilterByCreatedBefore returns a new list
+// of the models created before the time.
+func (data *ModelsData) FilterByCreatedBefore(t time.Time) ModelsData {
+	return data.filter(func(m *ModelDetails) bool {
+		return time.Unix(m.Created, 0).Before(t)
+	})
+}
+
+// GroupByOwner returns the new lists of the models grouped by the owner.
+func (data *ModelsData) GroupByOwner() map[string]ModelsData {
+	groups := map[string]ModelsData{}
+	for _, m := range *data {
+		if m != nil {
+			groups[m.OwnedBy] = append(groups[m.OwnedBy], m)
+		}
+	}
+	return groups
+}
+
+// OwnerSet returns the sorted unique owners of the models.
+func (data *ModelsData) OwnerSet() []string {
+	owners := []string{}
+	for owner := range data.GroupByOwner() {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// SortByCreated returns a new list of the models
+// sorted by the creation time.
+func (data *ModelsData) SortByCreated(ascending bool) ModelsData {
+	result := data.filter(func(*ModelDetails) bool { return true })
+	sort.SliceStable(result, func(i, j int) bool {
+		if ascending {
+			return result[i].Created < result[j].Created
+		}
+		return result[i].Created > result[j].Created
+	})
+	return result
+}
+
+// The filter returns a new list of the models that match the predicate.
+func (data *ModelsData) filter(fn func(*ModelDetails) bool) ModelsData {
+	result := ModelsData{}
+	for _, m := range *data {
+		if m != nil && fn(m) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestModelsPartialFailure tests that the failed models
+// are left out of the data returned by the Models.
+func TestModelsPartialFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/models/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"not found"}}`))
+			return
+		}
+
+		w.Write([]byte(`{"id":"` + id + `","object":"model"}`))
+	})
+
+	tests := []struct {
+		name   string
+		models []string
+		want   []string
+		errs   int
+	}{
+		{
+			name:   "all fetched",
+			models: []string{"gpt-4", "gpt-3.5-turbo"},
+			want:   []string{"gpt-4", "gpt-3.5-turbo"},
+		},
+		{
+			name:   "one failed",
+			models: []string{"gpt-4", "missing", "gpt-3.5-turbo"},
+			want:   []string{"gpt-4", "gpt-3.5-turbo"},
+			errs:   1,
+		},
+		{
+			name:   "all failed",
+			models: []string{"missing", "missing"},
+			want:   []string{},
+			errs:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := c.Models(tt.models...)
+
+			var multiErr *MultiError
+			switch {
+			case tt.errs == 0 && err != nil:
+				t.Fatalf("Models() error = %v", err)
+			case tt.errs != 0 && !errors.As(err, &multiErr):
+				t.Fatalf("Models() error = %v, want *MultiError", err)
+			case tt.errs != 0 && len(multiErr.Errors) != tt.errs:
+				t.Fatalf("Models() errors = %d, want %d",
+					len(multiErr.Errors), tt.errs)
+			}
+
+			if got := data.Names(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Names() = %v, want %v", got, tt.want)
+			}
+
+			if got := data.SortByCreated(true); got.Len() != len(tt.want) {
+				t.Errorf("SortByCreated() len = %d, want %d",
+					got.Len(), len(tt.want))
+			}
+		})
+	}
+}
+
+// TestModelsDataNilSafe tests that the methods
+// of the ModelsData skip the nil entries.
+func TestModelsDataNilSafe(t *testing.T) {
+	data := ModelsData{nil, {ID: "gpt-4", OwnedBy: "openai"}, nil}
+
+	if got := data.Names(); !reflect.DeepEqual(got, []string{"gpt-4"}) {
+		t.Errorf("Names() = %v", got)
+	}
+
+	if got := data.SortByCreated(false); got.Len() != 1 {
+		t.Errorf("SortByCreated() len = %d, want 1", got.Len())
+	}
+
+	if got := data.FilterByOwner("openai"); got.Len() != 1 {
+		t.Errorf("FilterByOwner() len = %d, want 1", got.Len())
+	}
+
+	if got := data.OwnerSet(); !reflect.DeepEqual(got, []string{"openai"}) {
+		t.Errorf("OwnerSet() = %v", got)
+	}
+}
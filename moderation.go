@@ -1,17 +1,37 @@
 package openai
 
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// moderationBatchSize is the default number of inputs
+	// in a single request of the ModerationBatch.
+	moderationBatchSize = 32
+
+	// moderationModel is the default model of the ModerationBatch
+	// and of the Moderate shortcuts.
+	moderationModel = "text-moderation-latest"
+)
+
 // Check if ModerationRequest implements Requester interface.
 var _ Requester = (*ModerationRequest)(nil)
 
 // ModerationRequest represents a request to the OpenAI Moderation API.
 type ModerationRequest struct {
-	// The input text to classify. This is required.
-	Input string `json:"input"`
+	// The input text to classify, a string or a []string to classify
+	// several texts in a single request. This is required.
+	Input interface{} `json:"input"`
 
 	// The model to use for the request. Two content moderations models are
 	// available: text-moderation-stable and text-moderation-latest.
 	// Defaults to text-moderation-latest.
 	Model string `json:"model,omitempty"`
+
+	// Timeout limits the duration of the request, the timeout of the
+	// client is used if it is zero. It isn't sent to the API.
+	Timeout time.Duration `json:"-"`
 }
 
 // ModerationResult represents a single result from the moderation response.
@@ -38,9 +58,42 @@ type ModerationResponse struct {
 	Results []ModerationResult `json:"results"`
 }
 
+// ModerationBatchOptions are the options of the ModerationBatch.
+type ModerationBatchOptions struct {
+	// The maximum number of inputs in a single request, 32 by default.
+	BatchSize int
+
+	// The model to use, text-moderation-latest by default.
+	Model string
+}
+
+// ModerationBatchSummary is the summary of the ModerationBatch results.
+type ModerationBatchSummary struct {
+	// The number of the flagged inputs.
+	TotalFlagged int
+
+	// The number of the inputs flagged under each category.
+	ByCategory map[string]int
+}
+
 // Error returns an error if the request is invalid.
 func (r *ModerationRequest) Error() error {
-	if r.Input == "" {
+	switch input := r.Input.(type) {
+	case string:
+		if input == "" {
+			return ErrInputRequired
+		}
+	case []string:
+		if len(input) == 0 {
+			return ErrInputRequired
+		}
+
+		for _, v := range input {
+			if v == "" {
+				return ErrInputRequired
+			}
+		}
+	default:
 		return ErrInputRequired
 	}
 
@@ -56,6 +109,11 @@ func (r *ModerationRequest) Error() error {
 func (r *ModerationRequest) Flush() {
 }
 
+// The timeout returns the timeout of the request.
+func (r *ModerationRequest) timeout() time.Duration {
+	return r.Timeout
+}
+
 // IsFlagged returns true if the input was flagged under any category.
 func (r *ModerationResponse) IsFlagged() bool {
 	for _, result := range r.Results {
@@ -65,3 +123,56 @@ func (r *ModerationResponse) IsFlagged() bool {
 	}
 	return false
 }
+
+// FlaggedCategories returns the sorted list of the categories
+// under which any input was flagged.
+func (r *ModerationResponse) FlaggedCategories() []string {
+	seen := map[string]bool{}
+	categories := []string{}
+	for _, result := range r.Results {
+		for category, flagged := range result.Categories {
+			if flagged && !seen[category] {
+				seen[category] = true
+				categories = append(categories, category)
+			}
+		}
+	}
+
+	sort.Strings(categories)
+	return categories
+}
+
+// HighestScore returns the category with the highest score of the result.
+// It returns an empty category and zero score if there are no scores.
+func (r *ModerationResult) HighestScore() (category string, score float64) {
+	for c, s := range r.CategoryScores {
+		// Compare the names of the equal scores
+		// to make the result deterministic.
+		if category == "" || s > score || (s == score && c < category) {
+			category, score = c, s
+		}
+	}
+
+	return category, score
+}
+
+// The newModerationBatchSummary counts the flagged results.
+func newModerationBatchSummary(
+	results []*ModerationResult,
+) *ModerationBatchSummary {
+	summary := &ModerationBatchSummary{ByCategory: map[string]int{}}
+	for _, result := range results {
+		if result == nil || !result.Flagged {
+			continue
+		}
+
+		summary.TotalFlagged++
+		for category, flagged := range result.Categories {
+			if flagged {
+				summary.ByCategory[category]++
+			}
+		}
+	}
+
+	return summary
+}
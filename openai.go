@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"fmt"
 	"runtime"
 	"time"
 )
@@ -32,6 +33,19 @@ const (
 	// values (closer to 1) make output more random, while lower values (closer
 	// to 0) make it more deterministic.
 	responseTemperature = 0.5
+
+	// streamBufferSize sets the default buffer size of the channels
+	// returned by the streaming methods. The buffer lets the stream
+	// be read ahead of a slow consumer.
+	streamBufferSize = 64
+
+	// userAgent is the User-Agent header of the requests
+	// that download the generated images.
+	userAgent = "goloop-openai"
+
+	// maxRedirects is the maximum number of redirects
+	// followed when downloading the generated images.
+	maxRedirects = 10
 )
 
 // newWithStringParams creates a new OpenAI API client using simple parameters.
@@ -164,3 +178,20 @@ func New[T Config | string](v T, opts ...T) *Client {
 
 	return newWithStringParams(data...)
 }
+
+// NewOrPanic creates a new OpenAI API client like New, but validates
+// the configuration first and panics if it is incorrect. It is suitable
+// for CLI tools and init functions that should fail fast.
+//
+// Example usage:
+//
+//	client := openai.NewOrPanic(openai.Config{
+//	    APIKey: os.Getenv("OPENAI_API_KEY"),
+//	})
+func NewOrPanic(config Config) *Client {
+	if err := config.Validate(); err != nil {
+		panic(fmt.Sprintf("openai: invalid configuration: %v", err))
+	}
+
+	return New(config)
+}
@@ -0,0 +1,154 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Option configures the OpenAI API client created by NewClient.
+type Option func(*Client)
+
+// NewClient creates a new OpenAI API client using the functional options.
+// The options are applied in the order they are passed, the parameters
+// that aren't set by the options get the default values. It returns
+// an error if the resulting configuration is incorrect.
+//
+// Example usage:
+//
+//	client, err := openai.NewClient(
+//	    openai.WithAPIKey("api-key"),
+//	    openai.WithOrgID("org-id"),
+//	    openai.WithTimeout(30*time.Second),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewClient(opts ...Option) (*Client, error) {
+	cli := &Client{}
+	cli.Configure(Config{})
+
+	for _, opt := range opts {
+		opt(cli)
+	}
+
+	if err := cli.Error(); err != nil {
+		return nil, err
+	}
+
+	return cli, nil
+}
+
+// WithConfig sets all the non-empty parameters of the configuration.
+func WithConfig(config Config) Option {
+	return func(c *Client) {
+		c.Configure(config)
+	}
+}
+
+// WithAPIKey sets the secret key for authorization.
+func WithAPIKey(apiKey string) Option {
+	return WithConfig(Config{APIKey: apiKey})
+}
+
+// WithOrgID sets the unique identifier of the organization.
+func WithOrgID(orgID string) Option {
+	return WithConfig(Config{OrgID: orgID})
+}
+
+// WithAPIBaseURL sets the base URL of OpenAI API.
+func WithAPIBaseURL(apiBaseURL string) Option {
+	return WithConfig(Config{APIBaseURL: apiBaseURL})
+}
+
+// WithInsecureHTTP allows the http scheme of the API base URL
+// for the hosts other than localhost.
+func WithInsecureHTTP() Option {
+	return WithConfig(Config{InsecureHTTP: true})
+}
+
+// WithParallelTasks sets the number of parallel requests.
+func WithParallelTasks(n int) Option {
+	return WithConfig(Config{ParallelTasks: n})
+}
+
+// WithTimeout sets the maximum duration time for a request.
+// It should be passed after WithHTTPClient, as the new HTTP
+// client comes with its own timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return WithConfig(Config{RequestTimeout: timeout})
+}
+
+// WithContext sets the context for requests.
+func WithContext(ctx context.Context) Option {
+	return WithConfig(Config{Context: ctx})
+}
+
+// WithHTTPHeaders sets the additional HTTP headers for requests.
+func WithHTTPHeaders(headers http.Header) Option {
+	return WithConfig(Config{HTTPHeaders: headers})
+}
+
+// WithHTTPClient sets the HTTP client for sending requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return WithConfig(Config{HTTPClient: httpClient})
+}
+
+// WithStreamBufferSize sets the buffer size of the streaming channels.
+func WithStreamBufferSize(size int) Option {
+	return WithConfig(Config{StreamBufferSize: size})
+}
+
+// WithValidateOnCreate enables the API reachability check
+// in the ValidateConfig method.
+func WithValidateOnCreate() Option {
+	return WithConfig(Config{ValidateOnCreate: true})
+}
+
+// WithRetry sets the policy of retrying failed requests.
+func WithRetry(policy RetryPolicy) Option {
+	return WithConfig(Config{RetryPolicy: policy})
+}
+
+// WithRateLimiter sets the limiter that throttles the requests.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return WithConfig(Config{RateLimiter: limiter})
+}
+
+// WithDefaultModel sets the model of the completion, chat completion
+// and edit requests that don't specify one.
+func WithDefaultModel(model string) Option {
+	return WithConfig(Config{DefaultModel: model})
+}
+
+// WithForceHTTP2 makes the default HTTP client use HTTP/2.
+func WithForceHTTP2() Option {
+	return WithConfig(Config{ForceHTTP2: true})
+}
+
+// WithMaxIdleConnsPerHost sets the number of the idle connections
+// of the default HTTP client kept for reuse.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return WithConfig(Config{MaxIdleConnsPerHost: n})
+}
+
+// WithProxyURL sets the URL of the HTTP or SOCKS5 proxy of the requests.
+func WithProxyURL(proxyURL string) Option {
+	return WithConfig(Config{ProxyURL: proxyURL})
+}
+
+// WithTracer sets the tracer that starts a span for each API call,
+// see the Tracer interface for the OpenTelemetry adapter.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.apiTracer = tracer
+	}
+}
+
+// WithMiddleware registers the middlewares
+// that intercept all HTTP requests of the client.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(mw...)
+	}
+}
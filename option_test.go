@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   Option
+		check func(c *Client) bool
+	}{
+		{
+			name:  "WithInsecureHTTP",
+			opt:   WithInsecureHTTP(),
+			check: func(c *Client) bool { return c.insecureHTTP },
+		},
+		{
+			name: "WithDefaultModel",
+			opt:  WithDefaultModel("gpt-4o"),
+			check: func(c *Client) bool {
+				return c.DefaultModel() == "gpt-4o"
+			},
+		},
+		{
+			name: "WithForceHTTP2",
+			opt:  WithForceHTTP2(),
+			check: func(c *Client) bool {
+				t, ok := c.HTTPClient().Transport.(*http.Transport)
+				return c.forceHTTP2 && ok && t.ForceAttemptHTTP2
+			},
+		},
+		{
+			name: "WithMaxIdleConnsPerHost",
+			opt:  WithMaxIdleConnsPerHost(32),
+			check: func(c *Client) bool {
+				t, ok := c.HTTPClient().Transport.(*http.Transport)
+				return ok && t.MaxIdleConnsPerHost == 32
+			},
+		},
+		{
+			name: "WithProxyURL",
+			opt:  WithProxyURL("http://proxy:8080"),
+			check: func(c *Client) bool {
+				t, ok := c.HTTPClient().Transport.(*http.Transport)
+				return c.proxyURL == "http://proxy:8080" && ok && t.Proxy != nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(WithAPIKey("test-key"), tt.opt)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			if !tt.check(c) {
+				t.Errorf("%s isn't applied", tt.name)
+			}
+		})
+	}
+}
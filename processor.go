@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+)
+
+// ResponseProcessor is a function that transforms the response text.
+// Processors can be combined in a pipeline with the Process method
+// of the ChatCompletionResponse.
+type ResponseProcessor func(string) (string, error)
+
+// TrimSpace is a response processor that removes
+// leading and trailing whitespace from the text.
+func TrimSpace(s string) (string, error) {
+	return strings.TrimSpace(s), nil
+}
+
+// RemoveMarkdownCodeFences is a response processor that removes the
+// markdown code fences (``` or ```lang lines) from the text, leaving
+// the code itself.
+func RemoveMarkdownCodeFences(s string) (string, error) {
+	lines := strings.Split(s, "\n")
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n"), nil
+}
+
+// ExtractJSON is a response processor that extracts the first valid
+// JSON object or array from the text, e.g. from the answer like
+// "Here is the result: {...}". It returns ErrNoJSON if the text
+// doesn't contain JSON.
+func ExtractJSON(s string) (string, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' && s[i] != '[' {
+			continue
+		}
+
+		var raw json.RawMessage
+		dec := json.NewDecoder(strings.NewReader(s[i:]))
+		if err := dec.Decode(&raw); err == nil {
+			return string(raw), nil
+		}
+	}
+
+	return "", ErrNoJSON
+}
+
+// UnescapeHTML is a response processor that unescapes
+// HTML entities like "&lt;" or "&amp;" in the text.
+func UnescapeHTML(s string) (string, error) {
+	return html.UnescapeString(s), nil
+}
@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"strings"
+	"text/template"
+)
+
+// PromptBuilder composes the prompt text from a template of the
+// text/template package. The values are substituted as is, so the
+// text can contain any characters, and the template can have the
+// conditional sections. The template is parsed by the Build method,
+// so the variables can be set in any order.
+//
+// Example usage:
+//
+//	msg := openai.NewPromptBuilder(
+//	    "Translate to {{.lang}}:{{if .formal}} use formal style.{{end}}",
+//	).
+//	    Set("lang", "French").
+//	    Set("formal", "yes").
+//	    AsUserMessage()
+type PromptBuilder struct {
+	template string
+	vars     map[string]string
+}
+
+// NewPromptBuilder creates a new prompt builder for the template.
+// The variables are referenced in the template as {{.name}}.
+func NewPromptBuilder(template string) *PromptBuilder {
+	return &PromptBuilder{
+		template: template,
+		vars:     map[string]string{},
+	}
+}
+
+// Set sets the value of the variable.
+func (b *PromptBuilder) Set(key, value string) *PromptBuilder {
+	b.vars[key] = value
+	return b
+}
+
+// SetAll sets the values of all the variables of the map.
+func (b *PromptBuilder) SetAll(vars map[string]string) *PromptBuilder {
+	for k, v := range vars {
+		b.vars[k] = v
+	}
+	return b
+}
+
+// Build executes the template with the variables. It returns an
+// error if the template is invalid or uses a variable that isn't set.
+func (b *PromptBuilder) Build() (string, error) {
+	tmpl, err := template.New("prompt").
+		Option("missingkey=error").
+		Parse(b.template)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, b.vars); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// AsSystemMessage returns the built prompt as a system message.
+// The content is empty if the prompt can't be built, use the
+// Build method to get the error.
+func (b *PromptBuilder) AsSystemMessage() ChatCompletionMessage {
+	return b.asMessage("system")
+}
+
+// AsUserMessage returns the built prompt as a user message.
+// The content is empty if the prompt can't be built, use the
+// Build method to get the error.
+func (b *PromptBuilder) AsUserMessage() ChatCompletionMessage {
+	return b.asMessage("user")
+}
+
+// AsAssistantMessage returns the built prompt as an assistant message.
+// The content is empty if the prompt can't be built, use the Build
+// method to get the error.
+func (b *PromptBuilder) AsAssistantMessage() ChatCompletionMessage {
+	return b.asMessage("assistant")
+}
+
+// The asMessage returns the built prompt as a message with the role.
+func (b *PromptBuilder) asMessage(role string) ChatCompletionMessage {
+	content, _ := b.Build()
+	return ChatCompletionMessage{Role: role, Content: content}
+}
@@ -0,0 +1,120 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Check if TokenBucketRateLimiter implements RateLimiter interface.
+var _ RateLimiter = (*TokenBucketRateLimiter)(nil)
+
+// bytesPerToken is the approximate number of bytes of the request
+// body per token, used to estimate the token count of the request.
+const bytesPerToken = 4
+
+// RateLimiter throttles the requests to the OpenAI API.
+//
+// The Wait method blocks until the request with the given estimated
+// number of tokens is allowed to be sent, or returns the context
+// error if the context is cancelled earlier.
+type RateLimiter interface {
+	Wait(ctx context.Context, tokens int) error
+}
+
+// TokenBucketRateLimiter is a RateLimiter that enforces the
+// requests-per-minute and tokens-per-minute limits using two
+// token buckets of the golang.org/x/time/rate package, which
+// are refilled continuously.
+//
+// Example usage:
+//
+//	client := openai.New(openai.Config{
+//	    APIKey:      "YOUR_API_KEY",
+//	    RateLimiter: openai.NewTokenBucketRateLimiter(500, 30000),
+//	})
+type TokenBucketRateLimiter struct {
+	requests *rate.Limiter // nil if the requests aren't limited
+	tokens   *rate.Limiter // nil if the tokens aren't limited
+}
+
+// NewTokenBucketRateLimiter creates a new rate limiter that allows
+// rpm requests and tpm tokens per minute. A zero or negative
+// value disables the corresponding limit.
+func NewTokenBucketRateLimiter(rpm, tpm int) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{
+		requests: newPerMinuteLimiter(rpm),
+		tokens:   newPerMinuteLimiter(tpm),
+	}
+}
+
+// Wait implements the RateLimiter interface.
+func (l *TokenBucketRateLimiter) Wait(ctx context.Context, tokens int) error {
+	now := time.Now()
+
+	// Reserve from both buckets at once, so the request
+	// doesn't hold the quota while waiting for another.
+	var delay time.Duration
+	reservations := make([]*rate.Reservation, 0, 2)
+	for _, r := range []struct {
+		limiter *rate.Limiter
+		n       int
+	}{
+		{l.requests, 1},
+		{l.tokens, tokens},
+	} {
+		if r.limiter == nil || r.n <= 0 {
+			continue
+		}
+
+		// Requests larger than the capacity wait for the full
+		// bucket only, otherwise they would never be allowed.
+		res := r.limiter.ReserveN(now, min(r.n, r.limiter.Burst()))
+		reservations = append(reservations, res)
+		delay = max(delay, res.DelayFrom(now))
+	}
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// The unused quota is returned to the buckets.
+		for _, res := range reservations {
+			res.Cancel()
+		}
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// The newPerMinuteLimiter creates a full bucket for the given number
+// of tokens per minute, or returns nil if the number isn't positive.
+func newPerMinuteLimiter(perMinute int) *rate.Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute)
+}
+
+// The requestTokens estimates the number of tokens of the request from
+// the size of its body. Only the text generation requests are charged,
+// the uploads of files and audio don't use the tokens-per-minute quota.
+func requestTokens(req *http.Request) int {
+	isTextGeneration := strings.HasSuffix(req.URL.Path, "/completions") ||
+		strings.HasSuffix(req.URL.Path, "/edits")
+	if !isTextGeneration || req.ContentLength <= 0 {
+		return 0
+	}
+
+	return int(req.ContentLength / bytesPerToken)
+}
@@ -0,0 +1,105 @@
+package openai
+
+import "encoding/json"
+
+// The types of the response format.
+const (
+	ResponseFormatTypeText       = "text"
+	ResponseFormatTypeJSONObject = "json_object"
+	ResponseFormatTypeJSONSchema = "json_schema"
+)
+
+// ResponseFormat specifies the format that the model must output.
+type ResponseFormat struct {
+	// The type of the response format: "text", "json_object"
+	// (JSON mode) or "json_schema" (Structured Outputs).
+	Type string `json:"type"`
+
+	// The JSON Schema the output must follow,
+	// required if the Type is "json_schema".
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema describes the structure of the model output
+// for the "json_schema" response format.
+type JSONSchema struct {
+	// The name of the response format. Must be a-z, A-Z, 0-9,
+	// or contain underscores and dashes, with a maximum length of 64.
+	Name string `json:"name"`
+
+	// A description of what the response format is for, used by
+	// the model to determine how to respond in the format.
+	Description string `json:"description,omitempty"`
+
+	// The schema for the response format, described
+	// as a JSON Schema object.
+	Schema json.RawMessage `json:"schema"`
+
+	// Whether to enable strict schema adherence
+	// when generating the output.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ResponseFormatJSON returns the response format that enables JSON mode,
+// which guarantees the message the model generates is valid JSON.
+func ResponseFormatJSON() *ResponseFormat {
+	return &ResponseFormat{Type: ResponseFormatTypeJSONObject}
+}
+
+// ResponseFormatJSONSchema returns the response format that enables
+// Structured Outputs, which ensures the model output matches the schema.
+// The schema can be a JSON document as a string, []byte or
+// json.RawMessage, or any Go value that is marshalled to JSON.
+func ResponseFormatJSONSchema(
+	name string,
+	schema any,
+	strict bool,
+) (*ResponseFormat, error) {
+	var raw json.RawMessage
+
+	switch v := schema.(type) {
+	case string:
+		raw = json.RawMessage(v)
+	case []byte:
+		raw = json.RawMessage(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw = data
+	}
+
+	if !json.Valid(raw) {
+		return nil, ErrInvalidSchema
+	}
+
+	return &ResponseFormat{
+		Type: ResponseFormatTypeJSONSchema,
+		JSONSchema: &JSONSchema{
+			Name:   name,
+			Schema: raw,
+			Strict: strict,
+		},
+	}, nil
+}
+
+// Error returns an error if the response format is invalid.
+func (rf *ResponseFormat) Error() error {
+	switch rf.Type {
+	case ResponseFormatTypeText, ResponseFormatTypeJSONObject:
+		return nil
+	case ResponseFormatTypeJSONSchema:
+		if rf.JSONSchema == nil || len(rf.JSONSchema.Schema) == 0 {
+			return ErrSchemaRequired
+		}
+
+		if !json.Valid(rf.JSONSchema.Schema) {
+			return ErrInvalidSchema
+		}
+
+		return nil
+	}
+
+	return ErrInvalidResponseFormat
+}
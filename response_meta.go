@@ -0,0 +1,98 @@
+package openai
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta holds the metadata of the API response: the rate
+// limit state, the request ID and the processing time reported
+// by the server in the response headers.
+//
+// Example usage:
+//
+//	resp, meta, err := client.ChatCompletionWithMeta(r)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	log.Println(meta.RequestID(), meta.RateLimitRemaining())
+type ResponseMeta struct {
+	statusCode int         // status code of the response
+	header     http.Header // headers of the response
+	receivedAt time.Time   // time the response was received
+}
+
+// The newResponseMeta creates the metadata of the response.
+func newResponseMeta(resp *http.Response) *ResponseMeta {
+	return &ResponseMeta{
+		statusCode: resp.StatusCode,
+		header:     resp.Header.Clone(),
+		receivedAt: time.Now(),
+	}
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (m *ResponseMeta) StatusCode() int {
+	return m.statusCode
+}
+
+// Headers returns the headers of the response.
+func (m *ResponseMeta) Headers() http.Header {
+	return m.header
+}
+
+// RequestID returns the unique ID of the request,
+// it's useful when contacting the OpenAI support.
+func (m *ResponseMeta) RequestID() string {
+	return m.header.Get("X-Request-Id")
+}
+
+// RateLimitRemaining returns the number of requests that can be sent
+// before the rate limit is exhausted, or -1 if it isn't reported.
+func (m *ResponseMeta) RateLimitRemaining() int {
+	n, err := strconv.Atoi(m.header.Get("X-Ratelimit-Remaining-Requests"))
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
+
+// RateLimitReset returns the time the rate limit of the requests
+// is reset to its initial state, or the zero time if it isn't reported.
+func (m *ResponseMeta) RateLimitReset() time.Time {
+	// The value is a duration, e.g. "1s" or "6m0s".
+	d, err := time.ParseDuration(m.header.Get("X-Ratelimit-Reset-Requests"))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return m.receivedAt.Add(d)
+}
+
+// RetryAfter returns the delay the server asks to wait before the next
+// request, e.g. of the rate limited one, or 0 if it isn't reported.
+func (m *ResponseMeta) RetryAfter() time.Duration {
+	delay, _ := retryAfter(m.header)
+	return delay
+}
+
+// ProcessingMs returns the time the server spent processing
+// the request in milliseconds, or -1 if it isn't reported.
+func (m *ResponseMeta) ProcessingMs() int {
+	n, err := strconv.Atoi(m.header.Get("Openai-Processing-Ms"))
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
+
+// OrgID returns the ID of the organization that processed the request,
+// or an empty string if it isn't reported. It may differ from the OrgID
+// of the client if the API key belongs to another organization.
+func (m *ResponseMeta) OrgID() string {
+	return m.header.Get("Openai-Organization")
+}
@@ -0,0 +1,78 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseMeta(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantErr    bool
+		wantRetry  time.Duration
+		wantRemain int
+	}{
+		{
+			name:       "success",
+			status:     http.StatusOK,
+			body:       `{"id":"thread_abc","object":"thread"}`,
+			wantRemain: 99,
+		},
+		{
+			name:       "rate limit",
+			status:     http.StatusTooManyRequests,
+			body:       `{"error":{"message":"slow down"}}`,
+			wantErr:    true,
+			wantRetry:  2 * time.Second,
+			wantRemain: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req_abc")
+				w.Header().Set("X-Ratelimit-Remaining-Requests", "99")
+				if tt.status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "2")
+					w.Header().Set("X-Ratelimit-Remaining-Requests", "0")
+				}
+
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, meta, err := c.ThreadRetrieveWithMeta("thread_abc")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ThreadRetrieveWithMeta() error = %v", err)
+			}
+
+			if meta == nil {
+				t.Fatal("ThreadRetrieveWithMeta() meta = nil")
+			}
+
+			if err != nil {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.Meta != meta {
+					t.Fatalf("error = %v, want *APIError with the meta", err)
+				}
+			}
+
+			if got := meta.RequestID(); got != "req_abc" {
+				t.Errorf("RequestID() = %q, want req_abc", got)
+			}
+
+			if got := meta.RateLimitRemaining(); got != tt.wantRemain {
+				t.Errorf("RateLimitRemaining() = %d, want %d", got, tt.wantRemain)
+			}
+
+			if got := meta.RetryAfter(); got != tt.wantRetry {
+				t.Errorf("RetryAfter() = %s, want %s", got, tt.wantRetry)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Check if ExponentialBackoffRetry implements RetryPolicy interface.
+var _ RetryPolicy = (*ExponentialBackoffRetry)(nil)
+
+const (
+	// retryBaseDelay is the default delay before the first retry.
+	retryBaseDelay = 500 * time.Millisecond
+
+	// retryMaxDelay is the default upper bound of the retry delay.
+	retryMaxDelay = 30 * time.Second
+)
+
+// RetryPolicy decides whether a failed request should be sent again.
+//
+// The ShouldRetry method is called after each attempt with the number
+// of attempts made so far (starting from 1), the HTTP response (may be
+// nil) and the transport error (may be nil). It returns true and the
+// delay before the next attempt if the request should be retried.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, resp *http.Response, err error) (bool, time.Duration)
+}
+
+// ExponentialBackoffRetry is a RetryPolicy that retries requests rejected
+// with 429, 500, 502, 503 or 504 status codes. The delay doubles with each
+// attempt and is randomized with jitter. If the response has a Retry-After
+// header, its value is used as the delay instead.
+//
+// Example usage:
+//
+//	client := openai.New(openai.Config{
+//	    APIKey:      "YOUR_API_KEY",
+//	    RetryPolicy: &openai.ExponentialBackoffRetry{MaxRetries: 3},
+//	})
+type ExponentialBackoffRetry struct {
+	MaxRetries int           // maximum number of retries
+	BaseDelay  time.Duration // delay before the first retry, 500ms by default
+	MaxDelay   time.Duration // upper bound of the delay, 30s by default
+}
+
+// ShouldRetry implements the RetryPolicy interface.
+func (p *ExponentialBackoffRetry) ShouldRetry(
+	attempt int,
+	resp *http.Response,
+	err error,
+) (bool, time.Duration) {
+	if attempt > p.MaxRetries || err != nil || resp == nil {
+		return false, 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+	default:
+		return false, 0
+	}
+
+	if delay, ok := retryAfter(resp.Header); ok {
+		return true, delay
+	}
+
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = retryBaseDelay
+	}
+
+	if max <= 0 {
+		max = retryMaxDelay
+	}
+
+	// The delay is base * 2^(attempt-1), but not more than max.
+	delay := time.Duration(float64(base) * math.Pow(2, float64(attempt-1)))
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+
+	// Add jitter so that the clients that failed at the same
+	// time don't retry at the same time: [delay/2, delay).
+	half := delay / 2
+	if half > 0 {
+		delay = half + time.Duration(rand.Int63n(int64(half)))
+	}
+
+	return true, delay
+}
+
+// The retryAfter returns the delay from the Retry-After header of
+// the response. The header can contain either a number of seconds
+// or an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,155 @@
+package openai
+
+import "github.com/goloop/g"
+
+// Check if RunCreateRequest implements Requester interface.
+var _ Requester = (*RunCreateRequest)(nil)
+
+// The statuses of the run.
+const (
+	RunStatusQueued         = "queued"
+	RunStatusInProgress     = "in_progress"
+	RunStatusRequiresAction = "requires_action"
+	RunStatusCancelling     = "cancelling"
+	RunStatusCancelled      = "cancelled"
+	RunStatusFailed         = "failed"
+	RunStatusCompleted      = "completed"
+	RunStatusExpired        = "expired"
+)
+
+// terminalRunStatuses is a list of statuses after which
+// the run doesn't change anymore.
+var terminalRunStatuses = []string{
+	RunStatusCancelled,
+	RunStatusFailed,
+	RunStatusCompleted,
+	RunStatusExpired,
+}
+
+// RunCreateRequest represents a request to create
+// a run of the thread with the OpenAI Runs API.
+type RunCreateRequest struct {
+	// The ID of the assistant to use to execute this run.
+	// This is required.
+	AssistantID string `json:"assistant_id"`
+
+	// The ID of the model to use, overrides the model of the assistant.
+	Model string `json:"model,omitempty"`
+
+	// The instructions for the run, overrides
+	// the instructions of the assistant.
+	Instructions string `json:"instructions,omitempty"`
+
+	// The tools the assistant can use for this run,
+	// overrides the tools of the assistant.
+	Tools []Tool `json:"tools,omitempty"`
+
+	// Set of key-value pairs that can be attached to the run.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ToolOutput is the output of the tool call
+// submitted to the run that requires action.
+type ToolOutput struct {
+	// The ID of the tool call the output is submitted for.
+	ToolCallID string `json:"tool_call_id"`
+
+	// The output of the tool call.
+	Output string `json:"output"`
+}
+
+// runSubmitToolOutputsRequest is the body of
+// the request to submit the tool outputs.
+type runSubmitToolOutputsRequest struct {
+	ToolOutputs []ToolOutput `json:"tool_outputs"`
+}
+
+// RunSubmitToolOutputs contains the tool calls
+// that must be resolved to continue the run.
+type RunSubmitToolOutputs struct {
+	ToolCalls []ToolCall `json:"tool_calls"`
+}
+
+// RunRequiredAction contains details on the action
+// required to continue the run.
+type RunRequiredAction struct {
+	// The type of the action, currently always "submit_tool_outputs".
+	Type string `json:"type"`
+
+	// Details on the tool outputs needed for the run to continue.
+	SubmitToolOutputs RunSubmitToolOutputs `json:"submit_tool_outputs"`
+}
+
+// RunError is the last error of the run.
+type RunError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RunUsage is the usage statistics of the run.
+type RunUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// RunResponse represents a run object returned by the OpenAI Runs API.
+type RunResponse struct {
+	ID             string             `json:"id"`              // identifier of the run
+	Object         string             `json:"object"`          // always "thread.run"
+	CreatedAt      int64              `json:"created_at"`      // creation time
+	ThreadID       string             `json:"thread_id"`       // ID of the thread
+	AssistantID    string             `json:"assistant_id"`    // ID of the assistant
+	Status         string             `json:"status"`          // status of the run
+	RequiredAction *RunRequiredAction `json:"required_action"` // action to continue
+	LastError      *RunError          `json:"last_error"`      // last error, if any
+	ExpiresAt      int64              `json:"expires_at"`      // expiration time
+	StartedAt      int64              `json:"started_at"`      // start time
+	CancelledAt    int64              `json:"cancelled_at"`    // cancellation time
+	FailedAt       int64              `json:"failed_at"`       // failure time
+	CompletedAt    int64              `json:"completed_at"`    // completion time
+	Model          string             `json:"model"`           // model used
+	Tools          []Tool             `json:"tools"`           // tools used
+	Metadata       map[string]string  `json:"metadata"`        // attached metadata
+	Usage          *RunUsage          `json:"usage"`           // null until terminal
+	Instructions   string             `json:"instructions"`    // instructions used
+}
+
+// RunsData represents a list of runs.
+type RunsData []*RunResponse
+
+// RunListResponse represents the response from
+// the OpenAI Runs API with a list of runs.
+type RunListResponse struct {
+	Object  string   `json:"object"`   // always "list"
+	Data    RunsData `json:"data"`     // list of runs
+	FirstID string   `json:"first_id"` // ID of the first run
+	LastID  string   `json:"last_id"`  // ID of the last run
+	HasMore bool     `json:"has_more"` // whether there are more runs
+}
+
+// Error returns an error if the request is invalid.
+func (r *RunCreateRequest) Error() error {
+	if r.AssistantID == "" {
+		return ErrAssistantRequired
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *RunCreateRequest) Flush() {
+}
+
+// IsTerminal returns true if the run is in the terminal status:
+// cancelled, failed, completed or expired.
+func (r *RunResponse) IsTerminal() bool {
+	return g.In(r.Status, terminalRunStatuses...)
+}
+
+// RequiresAction returns true if the run waits
+// for the tool outputs to continue.
+func (r *RunResponse) RequiresAction() bool {
+	return r.Status == RunStatusRequiresAction
+}
@@ -0,0 +1,312 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goloop/g"
+)
+
+// The timeType is the type of the time values,
+// they are encoded as the RFC 3339 strings.
+var timeType = reflect.TypeOf(time.Time{})
+
+// The jsonSchema is a subset of the JSON Schema
+// that describes the Go types.
+type jsonSchema struct {
+	Type                 string            `json:"type,omitempty"`
+	Format               string            `json:"format,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	Items                *jsonSchema       `json:"items,omitempty"`
+	Properties           *schemaProperties `json:"properties,omitempty"`
+	Required             []string          `json:"required,omitempty"`
+	AdditionalProperties *jsonSchema       `json:"additionalProperties,omitempty"`
+}
+
+// The schemaProperties are the properties of the object
+// in the order of the fields of the struct.
+type schemaProperties struct {
+	names   []string
+	schemas map[string]*jsonSchema
+}
+
+// The add appends the property.
+func (p *schemaProperties) add(name string, schema *jsonSchema) {
+	p.names = append(p.names, name)
+	p.schemas[name] = schema
+}
+
+// MarshalJSON encodes the properties in their order.
+func (p *schemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(p.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// GenerateSchema returns the JSON Schema of the type of the v, e.g.
+// for the parameters of the function tool or the Structured Outputs.
+//
+// The strings are "string", the numbers are "number", the booleans
+// are "boolean", the slices are "array" and the structs and maps are
+// "object". The properties are named by the json tags of the fields,
+// the fields with the "-" tag and the unexported fields are skipped.
+// The fields are required unless they are pointers or have the
+// omitempty option. The description of the property is set by the
+// jsonschema tag, the rest of the tag is the description:
+//
+//	type Weather struct {
+//	    City string `json:"city" jsonschema:"description=City name, e.g. Kyiv"`
+//	    Unit *string `json:"unit" jsonschema:"description=celsius or fahrenheit"`
+//	}
+//
+//	schema, err := openai.GenerateSchema(Weather{})
+//
+// It returns an error wrapping ErrInvalidSchema if the type
+// is recursive or can't be encoded to JSON, e.g. a channel.
+func GenerateSchema(v interface{}) (json.RawMessage, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("%w: nil value", ErrInvalidSchema)
+	}
+
+	schema, err := typeSchema(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(schema)
+}
+
+// The typeSchema returns the schema of the type,
+// the seen are the structs that are being described.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) (*jsonSchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.Interface:
+		// Any JSON value matches the empty schema.
+		return &jsonSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		// The []byte is encoded as a base64 string.
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string"}, nil
+		}
+
+		items, err := typeSchema(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+
+		values, err := typeSchema(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if seen[t] {
+			return nil, fmt.Errorf("%w: recursive type %s", ErrInvalidSchema, t)
+		}
+
+		seen[t] = true
+		defer delete(seen, t)
+
+		schema := &jsonSchema{
+			Type: "object",
+			Properties: &schemaProperties{
+				schemas: map[string]*jsonSchema{},
+			},
+		}
+
+		if err := addStructFields(schema, t, seen); err != nil {
+			return nil, err
+		}
+
+		return schema, nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported type %s", ErrInvalidSchema, t)
+}
+
+// The schemaField is a property of the struct schema,
+// the depth is the level of the embedding of the field.
+type schemaField struct {
+	name     string
+	depth    int
+	tagged   bool
+	optional bool
+	schema   *jsonSchema
+}
+
+// The addStructFields adds the fields of the struct to the properties
+// of the schema, the fields of the embedded structs are promoted.
+//
+// The name conflicts are resolved like in encoding/json: the field
+// with the shallowest depth wins, the tagged one wins between the
+// fields of the same depth, and the rest of the conflicts are dropped.
+func addStructFields(
+	schema *jsonSchema,
+	t reflect.Type,
+	seen map[reflect.Type]bool,
+) error {
+	fields := []schemaField{}
+	if err := collectStructFields(&fields, t, 0, seen); err != nil {
+		return err
+	}
+
+	for i, field := range fields {
+		if dominantField(fields, field.name) != i {
+			continue
+		}
+
+		schema.Properties.add(field.name, field.schema)
+		if !field.optional {
+			schema.Required = append(schema.Required, field.name)
+		}
+	}
+
+	return nil
+}
+
+// The collectStructFields appends the fields of the struct
+// and of its embedded structs to the fields.
+func collectStructFields(
+	fields *[]schemaField,
+	t reflect.Type,
+	depth int,
+	seen map[reflect.Type]bool,
+) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		optional := field.Type.Kind() == reflect.Ptr ||
+			strings.Contains(options, "omitempty")
+
+		// The untagged embedded structs are inlined like in encoding/json.
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if field.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// The struct that embeds itself has its fields
+			// on the shallower depth already.
+			if seen[ft] {
+				continue
+			}
+
+			seen[ft] = true
+			err := collectStructFields(fields, ft, depth+1, seen)
+			delete(seen, ft)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		prop, err := typeSchema(field.Type, seen)
+		if err != nil {
+			return err
+		}
+
+		_, description, _ := strings.Cut(
+			field.Tag.Get("jsonschema"),
+			"description=",
+		)
+		prop.Description = description
+
+		*fields = append(*fields, schemaField{
+			name:     g.Value(name, field.Name),
+			depth:    depth,
+			tagged:   name != "",
+			optional: optional,
+			schema:   prop,
+		})
+	}
+
+	return nil
+}
+
+// The dominantField returns the index of the field with the name
+// that wins the name conflict, or -1 if none of them wins.
+func dominantField(fields []schemaField, name string) int {
+	dominant, count := -1, 0
+	for i, field := range fields {
+		if field.name != name {
+			continue
+		}
+
+		switch {
+		case dominant == -1,
+			field.depth < fields[dominant].depth,
+			field.depth == fields[dominant].depth &&
+				field.tagged && !fields[dominant].tagged:
+			dominant, count = i, 1
+		case field.depth == fields[dominant].depth &&
+			field.tagged == fields[dominant].tagged:
+			count++
+		}
+	}
+
+	if count > 1 {
+		return -1
+	}
+
+	return dominant
+}
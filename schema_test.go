@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"errors"
+	"testing"
+)
+
+type schemaSelf struct {
+	*schemaSelf
+	X string `json:"x"`
+}
+
+type schemaInner struct {
+	Name  string `json:"name"`
+	Value string
+	Both  string
+}
+
+type schemaOther struct {
+	Both  string
+	Value string `json:"Value"`
+}
+
+type schemaOuter struct {
+	schemaInner
+	*schemaOther
+	Name string `json:"name,omitempty"`
+}
+
+type schemaNode struct {
+	Next *schemaNode `json:"next"`
+}
+
+// TestGenerateSchema tests the embedded structs
+// and the name conflicts of their fields.
+func TestGenerateSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+		err  error
+	}{
+		{
+			name: "embeds itself",
+			v:    schemaSelf{},
+			want: `{"type":"object","properties":{"x":{"type":"string"}},` +
+				`"required":["x"]}`,
+		},
+		{
+			name: "shallower and tagged fields win",
+			v:    schemaOuter{},
+			want: `{"type":"object","properties":{` +
+				`"Value":{"type":"string"},"name":{"type":"string"}},` +
+				`"required":["Value"]}`,
+		},
+		{
+			name: "recursive field",
+			v:    schemaNode{},
+			err:  ErrInvalidSchema,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateSchema(tt.v)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GenerateSchema() error = %v, want %v", err, tt.err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("GenerateSchema() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package openai
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+)
+
+// similarityParallelRows is the number of vectors from which
+// the rows of the similarity matrix are computed in parallel.
+const similarityParallelRows = 256
+
+// EmbeddingMatch is an embedding found by the nearest-neighbour search.
+type EmbeddingMatch struct {
+	Index     int       // index of the embedding in the response
+	Score     float64   // cosine similarity to the query
+	Embedding []float64 // the embedding vector
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors,
+// a value from -1 to 1. It returns 0 if the vectors have different
+// lengths or one of them is a zero vector.
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Nearest returns up to topK embeddings that are the most similar
+// to the query by cosine similarity, ordered from the most similar.
+//
+// Example usage:
+//
+//	matches := resp.Nearest(query.Data[0].Embedding, 3)
+//	for _, m := range matches {
+//	    fmt.Println(texts[m.Index], m.Score)
+//	}
+func (r *EmbeddingResponse) Nearest(
+	query []float64,
+	topK int,
+) []EmbeddingMatch {
+	if topK <= 0 {
+		return []EmbeddingMatch{}
+	}
+
+	// The min-heap keeps the topK best matches,
+	// the worst of them is at the top.
+	h := make(matchHeap, 0, topK)
+	for _, e := range r.Data {
+		score := CosineSimilarity(query, e.Embedding)
+		if len(h) < topK {
+			heap.Push(&h, EmbeddingMatch{e.Index, score, e.Embedding})
+		} else if score > h[0].Score {
+			h[0] = EmbeddingMatch{e.Index, score, e.Embedding}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	matches := []EmbeddingMatch(h)
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
+
+// Normalize scales every embedding of the response to the unit length
+// in-place, so the dot product of the normalized vectors is equal
+// to their cosine similarity. The zero vectors are left as is.
+func (r *EmbeddingResponse) Normalize() EmbeddingResponse {
+	for _, e := range r.Data {
+		var norm float64
+		for _, v := range e.Embedding {
+			norm += v * v
+		}
+
+		if norm == 0 {
+			continue
+		}
+
+		norm = math.Sqrt(norm)
+		for i := range e.Embedding {
+			e.Embedding[i] /= norm
+		}
+	}
+
+	return *r
+}
+
+// The similarityMatrix returns the symmetric matrix of the pairwise
+// cosine similarities of the vectors, the diagonal is 1. For the large
+// matrices, the rows are computed by up to parallelTasks goroutines.
+func similarityMatrix(vectors [][]float64, parallelTasks int) [][]float64 {
+	var wg sync.WaitGroup
+
+	n := len(vectors)
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+
+	// Each row computes its part of the upper triangle
+	// and mirrors it, so every cell is written once.
+	row := func(i int) {
+		matrix[i][i] = 1
+		for j := i + 1; j < n; j++ {
+			score := CosineSimilarity(vectors[i], vectors[j])
+			matrix[i][j], matrix[j][i] = score, score
+		}
+	}
+
+	if n < similarityParallelRows || parallelTasks < 2 {
+		for i := 0; i < n; i++ {
+			row(i)
+		}
+
+		return matrix
+	}
+
+	sem := make(chan struct{}, parallelTasks)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			row(i)
+		}(i)
+	}
+
+	wg.Wait()
+	return matrix
+}
+
+// The matchHeap is a min-heap of the matches by score.
+type matchHeap []EmbeddingMatch
+
+func (h matchHeap) Len() int           { return len(h) }
+func (h matchHeap) Less(i, j int) bool { return h[i].Score < h[j].Score }
+func (h matchHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *matchHeap) Push(x any) {
+	*h = append(*h, x.(EmbeddingMatch))
+}
+
+func (h *matchHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
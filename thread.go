@@ -0,0 +1,84 @@
+package openai
+
+import "github.com/goloop/g"
+
+// Check if ThreadCreateRequest implements Requester interface.
+var _ Requester = (*ThreadCreateRequest)(nil)
+
+// availableThreadRoleList is a list of roles
+// of the messages that can be added to a thread.
+var availableThreadRoleList = []string{"user", "assistant"}
+
+// ThreadMessage represents an initial message of the thread.
+type ThreadMessage struct {
+	// The role of the entity that is creating the message:
+	// "user" or "assistant". This is required.
+	Role string `json:"role"`
+
+	// The content of the message. This is required.
+	Content string `json:"content"`
+
+	// Set of key-value pairs that can be attached to the message.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ThreadCreateRequest represents a request to create
+// a thread with the OpenAI Threads API.
+type ThreadCreateRequest struct {
+	// A list of messages to start the thread with. Optional.
+	Messages []ThreadMessage `json:"messages,omitempty"`
+
+	// Set of key-value pairs that can be attached to the thread.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ThreadModifyRequest represents a request to modify
+// a thread with the OpenAI Threads API.
+type ThreadModifyRequest struct {
+	// Set of key-value pairs that can be attached to the thread.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ThreadResponse represents a thread object
+// returned by the OpenAI Threads API.
+type ThreadResponse struct {
+	// The identifier of the thread.
+	ID string `json:"id"`
+
+	// The object type, which is always "thread".
+	Object string `json:"object"`
+
+	// The Unix timestamp (in seconds) when the thread was created.
+	CreatedAt int64 `json:"created_at"`
+
+	// Set of key-value pairs attached to the thread.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ThreadDeleteResponse represents the response from
+// the OpenAI Threads API when a thread is deleted.
+type ThreadDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+// Error returns an error if the request is invalid.
+func (r *ThreadCreateRequest) Error() error {
+	for _, message := range r.Messages {
+		if !g.In(message.Role, availableThreadRoleList...) {
+			return ErrInvalidRole
+		}
+
+		if message.Content == "" {
+			return ErrMessageRequired
+		}
+	}
+
+	return nil
+}
+
+// Flush does nothing.
+// This is here to satisfy the Requester interface.
+func (r *ThreadCreateRequest) Flush() {
+}
@@ -0,0 +1,194 @@
+package openai
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// tokensPerMessage is the overhead of each message
+	// for the role and the message separators.
+	tokensPerMessage = 4
+
+	// tokensPerName is the overhead of the name of the message.
+	tokensPerName = 1
+
+	// tokensReplyPriming is the overhead of priming
+	// the assistant reply.
+	tokensReplyPriming = 1
+
+	// tokensPerImage is the estimate of a low detail image,
+	// the high detail images can cost more.
+	tokensPerImage = 85
+)
+
+// The pieceRegexp splits the text into pieces like the pre-tokenizer of
+// the cl100k_base encoding: contractions, words with an optional leading
+// symbol, up to three digits, punctuation and whitespace.
+var pieceRegexp = regexp.MustCompile(
+	`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}|` +
+		` ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`,
+)
+
+// TokenEstimator estimates the number of tokens of the chat messages
+// without an API call. The text is encoded by the Tokenizer set by the
+// RegisterTokenizer function, so only the overhead of the messages is
+// estimated. If no tokenizer is registered, the text is split into
+// pieces the same way as the cl100k_base encoding does, and the tokens
+// of each piece are estimated by a length heuristic instead of the byte
+// pair merges. The heuristic estimate isn't the count of the API, it
+// can be noticeably off for code and non-English text, so register a
+// Tokenizer (e.g. a tiktoken adapter) for the exact counts, or leave
+// a margin below the context window of the model.
+//
+// Example usage:
+//
+//	estimator := openai.TokenEstimator{Model: "gpt-4o"}
+//	if !estimator.FitsInContext(messages, 8192) {
+//	    messages = estimator.Truncate(messages, 8192)
+//	}
+type TokenEstimator struct {
+	// The model ID the messages are sent to.
+	Model string
+}
+
+// Count returns the estimated number of prompt tokens of the messages,
+// including the overhead of each message and the reply priming.
+func (e *TokenEstimator) Count(messages []ChatCompletionMessage) (int, error) {
+	count := tokensReplyPriming
+	for i := range messages {
+		n, err := e.countMessage(&messages[i])
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+
+	return count, nil
+}
+
+// FitsInContext returns true if the estimated number of tokens
+// of the messages doesn't exceed the maxContextTokens.
+func (e *TokenEstimator) FitsInContext(
+	messages []ChatCompletionMessage,
+	maxContextTokens int,
+) bool {
+	count, err := e.Count(messages)
+	return err == nil && count <= maxContextTokens
+}
+
+// Truncate drops the oldest non-system messages until the estimated
+// number of tokens doesn't exceed the maxTokens. The system messages
+// are always kept. It returns a new slice, the messages aren't modified.
+func (e *TokenEstimator) Truncate(
+	messages []ChatCompletionMessage,
+	maxTokens int,
+) []ChatCompletionMessage {
+	counts := make([]int, len(messages))
+	total := tokensReplyPriming
+	for i := range messages {
+		counts[i], _ = e.countMessage(&messages[i])
+		total += counts[i]
+	}
+
+	// Mark the oldest non-system messages as dropped
+	// until the rest fits into the limit.
+	dropped := make([]bool, len(messages))
+	for i := 0; i < len(messages) && total > maxTokens; i++ {
+		if messages[i].Role == "system" {
+			continue
+		}
+
+		dropped[i] = true
+		total -= counts[i]
+	}
+
+	result := make([]ChatCompletionMessage, 0, len(messages))
+	for i, m := range messages {
+		if !dropped[i] {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+// The countMessage returns the estimated number
+// of tokens of the message with its overhead.
+func (e *TokenEstimator) countMessage(m *ChatCompletionMessage) (int, error) {
+	count := tokensPerMessage + e.textTokens(m.Role)
+	if m.Name != "" {
+		count += tokensPerName + e.textTokens(m.Name)
+	}
+
+	switch content := m.Content.(type) {
+	case nil:
+	case string:
+		count += e.textTokens(content)
+	case []ContentPart:
+		for _, part := range content {
+			if part.Type == "image_url" {
+				count += tokensPerImage
+			} else {
+				count += e.textTokens(part.Text)
+			}
+		}
+	default:
+		return 0, ErrInvalidContent
+	}
+
+	for _, call := range m.ToolCalls {
+		count += e.textTokens(call.Function.Name)
+		count += e.textTokens(call.Function.Arguments)
+	}
+
+	return count, nil
+}
+
+// The textTokens returns the number of tokens of the text encoded by
+// the registered tokenizer, or the heuristic estimate if no tokenizer
+// is registered or it can't encode the text for the model.
+func (e *TokenEstimator) textTokens(text string) int {
+	if t := tokenizer(); t != nil {
+		if ids, err := t.Encode(e.Model, text); err == nil {
+			return len(ids)
+		}
+	}
+
+	return estimateTokens(text)
+}
+
+// The estimateTokens returns the heuristic estimate of the number of
+// tokens of the text, it doesn't apply the cl100k_base byte pair merges.
+// The common short words are a single token, the longer words are split
+// into tokens of about five letters, the digits are grouped by three,
+// and the letters of non-Latin scripts are about a token each.
+func estimateTokens(text string) int {
+	count := 0
+	for _, piece := range pieceRegexp.FindAllString(text, -1) {
+		// The leading space is merged into the word token.
+		word := strings.TrimLeft(piece, " ")
+		switch {
+		case strings.TrimSpace(piece) == "":
+			count++
+		case !isASCII(word):
+			count += utf8.RuneCountInString(word)
+		default:
+			count += (len(word) + 4) / 5
+		}
+	}
+
+	return count
+}
+
+// The isASCII returns true if the text contains only ASCII characters.
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package openai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// The wordTokenizer encodes each word of the text into a token.
+type wordTokenizer struct {
+	err error
+}
+
+// Encode returns a token ID for each word of the text.
+func (t wordTokenizer) Encode(model, text string) ([]int, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return make([]int, len(strings.Fields(text))), nil
+}
+
+func TestTokenEstimatorCount(t *testing.T) {
+	messages := []ChatCompletionMessage{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: "What is the capital of Ukraine?"},
+	}
+
+	estimated, err := (&TokenEstimator{Model: "gpt-4o"}).Count(messages)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tokenizer Tokenizer
+		want      int
+	}{
+		{name: "estimated", want: estimated},
+		{
+			// The priming, and the overhead, role and words of each message.
+			name:      "registered tokenizer",
+			tokenizer: wordTokenizer{},
+			want:      1 + (4 + 1 + 5) + (4 + 1 + 6),
+		},
+		{
+			name:      "tokenizer fails",
+			tokenizer: wordTokenizer{err: errors.New("unknown model")},
+			want:      estimated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterTokenizer(tt.tokenizer)
+			t.Cleanup(func() { RegisterTokenizer(nil) })
+
+			e := &TokenEstimator{Model: "gpt-4o"}
+			got, err := e.Count(messages)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Count() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package openai
+
+import (
+	"strconv"
+	"sync"
+)
+
+var (
+	// registeredTokenizer encodes the text into the token IDs,
+	// it is set by the RegisterTokenizer function.
+	registeredTokenizer   Tokenizer
+	registeredTokenizerMu sync.RWMutex
+)
+
+// Tokenizer encodes the text into the token IDs of the model. The
+// package doesn't include the encodings, a tokenizer library can be
+// plugged in with a small adapter, e.g. for the tiktoken-go module:
+//
+//	type tiktokenTokenizer struct{}
+//
+//	func (tiktokenTokenizer) Encode(model, text string) ([]int, error) {
+//	    enc, err := tiktoken.EncodingForModel(model)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return enc.Encode(text, nil, nil), nil
+//	}
+//
+//	openai.RegisterTokenizer(tiktokenTokenizer{})
+type Tokenizer interface {
+	Encode(model, text string) ([]int, error)
+}
+
+// RegisterTokenizer sets the tokenizer used by the helpers that need
+// the token IDs, e.g. CompletionRequest.LogitBiasForTokens.
+func RegisterTokenizer(t Tokenizer) {
+	registeredTokenizerMu.Lock()
+	defer registeredTokenizerMu.Unlock()
+	registeredTokenizer = t
+}
+
+// The tokenizer returns the registered tokenizer, or nil.
+func tokenizer() Tokenizer {
+	registeredTokenizerMu.RLock()
+	defer registeredTokenizerMu.RUnlock()
+	return registeredTokenizer
+}
+
+// LogitBiasForTokens sets the bias of the token IDs of each text
+// in the LogitBias of the request. If a text is encoded into several
+// tokens, all of them get the bias. The bias must be from -100 to 100.
+// It returns ErrTokenizerUnavailable if no tokenizer is registered.
+func (r *CompletionRequest) LogitBiasForTokens(
+	model string,
+	tokens []string,
+	bias float64,
+) error {
+	if bias < -100 || bias > 100 {
+		return ErrInvalidLogitBias
+	}
+
+	t := tokenizer()
+	if t == nil {
+		return ErrTokenizerUnavailable
+	}
+
+	// The map is updated only if all texts are encoded.
+	logitBias := map[string]float64{}
+	for _, text := range tokens {
+		ids, err := t.Encode(model, text)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			logitBias[strconv.Itoa(id)] = bias
+		}
+	}
+
+	if r.LogitBias == nil {
+		r.LogitBias = map[string]float64{}
+	}
+
+	for id, b := range logitBias {
+		r.LogitBias[id] = b
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package openai
+
+import "encoding/json"
+
+const (
+	// ToolTypeFunction is the type of the function tool,
+	// the only type supported by the Chat Completions API.
+	ToolTypeFunction = "function"
+
+	// ToolTypeCodeInterpreter is the type of the code
+	// interpreter tool of the Assistants API.
+	ToolTypeCodeInterpreter = "code_interpreter"
+
+	// ToolTypeFileSearch is the type of the file
+	// search tool of the Assistants API.
+	ToolTypeFileSearch = "file_search"
+)
+
+// Tool represents a tool the model may call.
+type Tool struct {
+	// The type of the tool. The Chat Completions API supports only
+	// "function", the Assistants API also supports "code_interpreter"
+	// and "file_search" tools.
+	Type string `json:"type"`
+
+	// The definition of the function that can be called.
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a function the model may call.
+type FunctionDefinition struct {
+	// The name of the function to be called. Must be a-z, A-Z, 0-9,
+	// or contain underscores and dashes, with a maximum length of 64.
+	Name string `json:"name"`
+
+	// A description of what the function does, used by the model
+	// to choose when and how to call the function.
+	Description string `json:"description,omitempty"`
+
+	// The parameters the function accepts, described as
+	// a JSON Schema object.
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// MarshalJSON encodes the tool, the function definition
+// is encoded for the function tools only.
+func (t Tool) MarshalJSON() ([]byte, error) {
+	if t.Type != ToolTypeFunction {
+		return json.Marshal(struct {
+			Type string `json:"type"`
+		}{t.Type})
+	}
+
+	// The alias type has no methods, it prevents recursion.
+	type alias Tool
+	return json.Marshal(alias(t))
+}
+
+// ToolCall represents a call of the tool generated by the model.
+type ToolCall struct {
+	// The ID of the tool call. It is used to send
+	// the result of the call back to the model.
+	ID string `json:"id"`
+
+	// The type of the tool. Currently, only "function" is supported.
+	Type string `json:"type"`
+
+	// The function that the model called.
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction is the function that the model called.
+type ToolCallFunction struct {
+	// The name of the function to call.
+	Name string `json:"name"`
+
+	// The arguments to call the function with, as generated by the model
+	// in JSON format. Note that the model does not always generate valid
+	// JSON, so validate the arguments before calling the function.
+	Arguments string `json:"arguments"`
+}
+
+// ParseArguments decodes the arguments of the function call into v.
+func (f *ToolCallFunction) ParseArguments(v interface{}) error {
+	return json.Unmarshal([]byte(f.Arguments), v)
+}
@@ -1,13 +1,15 @@
 package openai
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"mime/multipart"
 	"net"
 	"net/http"
@@ -19,13 +21,31 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/goloop/g"
 )
 
 // The toImagePath modifies the image path to reflect the copy number
 // and additional suffixes if provided. It resolves relative paths,
 // and replaces ~ with the home directory path. If the provided path
-// is a directory, it generates a unique filename with a .png extension.
-func toImagePath(copy int, path string, sep ...string) (string, error) {
+// is a directory, it generates a unique filename with the ext extension
+// (.png if it's empty). The path is a file if it ends with one of the
+// validExtensions (validImageExtensions if it's nil).
+func toImagePath(
+	copy int,
+	path, ext string,
+	validExtensions []string,
+	sep ...string,
+) (string, error) {
+	if ext == "" {
+		ext = ".png"
+	}
+
+	if validExtensions == nil {
+		validExtensions = validImageExtensions
+	}
+
 	// Resolve ~ to the user's home directory.
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
@@ -43,7 +63,7 @@ func toImagePath(copy int, path string, sep ...string) (string, error) {
 
 	if strings.HasSuffix(absolutePath, "/") ||
 		strings.HasSuffix(absolutePath, "\\") ||
-		!strings.HasSuffix(absolutePath, ".png") {
+		!g.In(strings.ToLower(filepath.Ext(absolutePath)), validExtensions...) {
 
 		// Check if the provided path is a directory
 		info, err := os.Stat(absolutePath)
@@ -53,16 +73,16 @@ func toImagePath(copy int, path string, sep ...string) (string, error) {
 
 		if info.IsDir() {
 			// If the path is a directory, generate a unique filename
-			file, err := generateUniqueFilename()
+			file, err := generateUniqueFilename(absolutePath, ext)
 			if err != nil {
 				return "", err
 			}
-			return filepath.Join(absolutePath, file+".png"), nil
+			return filepath.Join(absolutePath, file+ext), nil
 		}
 	}
 
 	// Get file extension.
-	ext := filepath.Ext(absolutePath)
+	ext = filepath.Ext(absolutePath)
 
 	// File name without extension.
 	file := strings.TrimSuffix(filepath.Base(absolutePath), ext)
@@ -88,31 +108,75 @@ func toImagePath(copy int, path string, sep ...string) (string, error) {
 	return filepath.Join(dir, file), nil
 }
 
+// UniqueFilenameAttempts is the number of attempts to generate the name
+// of an image file that doesn't exist in the directory yet, when the
+// images are saved to a directory.
+var UniqueFilenameAttempts = 10
+
+// The randRead fills the random bytes of the generated filenames,
+// the tests replace it to generate the colliding names.
+var randRead = rand.Read
+
 // The generateUniqueFilename generates a unique filename using
-// the crypto/rand package from the Go standard library.
+// the crypto/rand package from the Go standard library. The name
+// with the ext extension must not exist in the dir, the generation
+// is retried up to UniqueFilenameAttempts times.
 //
 // Don't to use third-party libraries, for example github.com/google/uuid.
-func generateUniqueFilename() (string, error) {
-	b := make([]byte, 16)
-	_, err := rand.Read(b)
-	if err != nil {
-		return "", err
+func generateUniqueFilename(dir, ext string) (string, error) {
+	for i := 0; i < UniqueFilenameAttempts; i++ {
+		b := make([]byte, 16)
+		_, err := randRead(b)
+		if err != nil {
+			return "", err
+		}
+
+		uuid := fmt.Sprintf("%x", b)
+		_, err = os.Stat(filepath.Join(dir, uuid+ext))
+		if os.IsNotExist(err) {
+			return uuid, nil
+		} else if err != nil {
+			return "", err
+		}
 	}
 
-	uuid := fmt.Sprintf("%x", b)
-	return uuid, nil
+	return "", ErrCannotGenerateUniqueFilename
 }
 
-// saveByURL is a function that saves images from a list of URLs to the
-// specified path on the local filesystem. It takes the path to save the
-// images, the number of parallel tasks to execute, and a slice of URLs
-// as input.
-// It returns an error if there was any issue during the process.
-func saveByURL(path string, parallelTasks int, items []string) error {
+// saveByURLWithContext saves images from a list of URLs to the specified
+// path on the local filesystem. It downloads the images with the client,
+// at most parallelTasks at a time, the downloads are aborted
+// when the context is cancelled. If the context or the client is nil,
+// the background context or a default client with the request timeout
+// is used. At most maxRedirects redirects are followed for each image.
+func saveByURLWithContext(
+	ctx context.Context,
+	client *http.Client,
+	path string,
+	parallelTasks int,
+	items []string,
+) error {
 	var wg sync.WaitGroup
 	var errors []error
 	var errMutex sync.Mutex
 
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The copy of the client limits the redirects
+	// without modifying the client of the caller.
+	httpClient := http.Client{Timeout: requestTimeout}
+	if client != nil {
+		httpClient = *client
+	}
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+
 	// Create a semaphore with a maximum count of parallelTasks.
 	sem := make(chan struct{}, parallelTasks)
 
@@ -127,38 +191,11 @@ func saveByURL(path string, parallelTasks int, items []string) error {
 			// Release token when done.
 			defer func() { <-sem; wg.Done() }()
 
-			p, err := toImagePath(i, path)
-			if err != nil {
-				errMutex.Lock()
-				errors = append(errors, err)
-				errMutex.Unlock()
-				return
-			}
-
-			resp, err := http.Get(item)
-			if err != nil {
-				errMutex.Lock()
-				errors = append(errors, err)
-				errMutex.Unlock()
-				return
-			}
-			defer resp.Body.Close()
-
-			out, err := os.Create(p)
+			err := downloadImage(ctx, &httpClient, i, path, item)
 			if err != nil {
 				errMutex.Lock()
 				errors = append(errors, err)
 				errMutex.Unlock()
-				return
-			}
-			defer out.Close()
-
-			_, err = io.Copy(out, resp.Body)
-			if err != nil {
-				errMutex.Lock()
-				errors = append(errors, err)
-				errMutex.Unlock()
-				return
 			}
 		}(i, item)
 	}
@@ -173,6 +210,41 @@ func saveByURL(path string, parallelTasks int, items []string) error {
 	return nil
 }
 
+// The downloadImage downloads the image by URL
+// and saves it as the copy of the path.
+func downloadImage(
+	ctx context.Context,
+	client *http.Client,
+	copy int,
+	path, item string,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isSuccessfulCode(resp.StatusCode) {
+		return fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+	}
+
+	// The extension of the generated filename
+	// matches the format of the downloaded image.
+	ext := imageExtension(resp.Header.Get("Content-Type"))
+	p, err := toImagePath(copy, path, ext, nil)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(p, resp.Body)
+}
+
 // saveByBase64 is a function that saves images from a list of
 // base64-encoded strings to the specified path on the local filesystem.
 // It takes the path to save the images, the number of parallel
@@ -206,7 +278,9 @@ func saveByBase64(path string, parallelTasks int, items []string) error {
 				return
 			}
 
-			p, err := toImagePath(i, path)
+			// The format of the image is detected by its data.
+			ext := imageExtension(http.DetectContentType(dec))
+			p, err := toImagePath(i, path, ext, nil)
 			if err != nil {
 				errMutex.Lock()
 				errors = append(errors, err)
@@ -215,7 +289,7 @@ func saveByBase64(path string, parallelTasks int, items []string) error {
 			}
 
 			// Write bytes to file.
-			err = ioutil.WriteFile(p, dec, 0o644)
+			err = writeFileAtomic(p, bytes.NewReader(dec))
 			if err != nil {
 				errMutex.Lock()
 				errors = append(errors, err)
@@ -235,6 +309,75 @@ func saveByBase64(path string, parallelTasks int, items []string) error {
 	return nil
 }
 
+// The writeFileAtomic writes data from the reader to the file at the
+// specified path. The data is written to a temporary file in the same
+// directory first, which is then renamed to the destination, so the
+// destination file is never left in a partially-written state.
+// The temporary file is removed on any error.
+func writeFileAtomic(path string, r io.Reader) (err error) {
+	tmp, err := os.CreateTemp(
+		filepath.Dir(path),
+		"*"+filepath.Ext(path)+".tmp",
+	)
+	if err != nil {
+		return err
+	}
+
+	// Remove the temporary file if something went wrong.
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		return err
+	}
+
+	if err = tmp.Chmod(0o644); err != nil {
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	// On Unix, rename is atomic at the filesystem level.
+	return os.Rename(tmp.Name(), path)
+}
+
+// The createTempFile writes the data of the reader to a new temporary
+// file in the os.TempDir directory. The name of the file ends with the
+// base of the filename, so the API can detect the format of the file
+// by its extension. The returned file is positioned at the beginning.
+func createTempFile(r io.Reader, filename string) (*os.File, error) {
+	file, err := os.CreateTemp("", "openai-*-"+filepath.Base(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = io.Copy(file, r); err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// The removeTempFiles removes the temporary files
+// created by the createTempFile function.
+func removeTempFiles(paths []string) {
+	for _, p := range paths {
+		os.Remove(p)
+	}
+}
+
 // The urlBuild constructs a URL from a base URL as prefix
 // (like: https://some.site/) and an endpoint (or path parts).
 // The function returns an error as the second value if the URL
@@ -249,6 +392,32 @@ func urlBuild(baseURL string, pathParts ...string) (string, error) {
 	return u.String(), nil
 }
 
+// The urlBuildWithQuery builds the URL like urlBuild and appends
+// the query parameters to it. The parameters with empty values are
+// omitted, the existing query of the base URL is kept.
+func urlBuildWithQuery(
+	baseURL string,
+	query map[string]string,
+	pathParts ...string,
+) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join(u.Path, path.Join(pathParts...))
+
+	values := u.Query()
+	for k, v := range query {
+		if v != "" {
+			values.Set(k, v)
+		}
+	}
+
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
+
 // The isSuccessfulCode checks if the HTTP status code is successful.
 func isSuccessfulCode(statusCode int) bool {
 	// Different endpoints has different successful status code,
@@ -256,6 +425,26 @@ func isSuccessfulCode(statusCode int) bool {
 	return statusCode >= http.StatusOK && statusCode < http.StatusBadRequest
 }
 
+// The authorizer is implemented by the clients that can authorize
+// requests in their own way instead of the bearer token, e.g. the
+// clients of the Azure OpenAI Service. The authorize method returns
+// false if the default authorization should be used.
+type authorizer interface {
+	authorize(req *http.Request) bool
+}
+
+// The setAuthHeaders sets the authorization headers of the request.
+func setAuthHeaders(c Clienter, req *http.Request) {
+	if a, ok := c.(authorizer); ok && a.authorize(req) {
+		return
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey()))
+	if orgID := c.OrgID(); orgID != "" {
+		req.Header.Set("OpenAI-Organization", orgID)
+	}
+}
+
 // newJSONRequest creates a new HTTP request instance.
 func newJSONRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 	var body io.Reader
@@ -277,10 +466,8 @@ func newJSONRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 
 	// Set the request headers.
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey()))
-	if orgID := c.OrgID(); orgID != "" {
-		req.Header.Set("OpenAI-Organization", orgID)
-	}
+	setAuthHeaders(c, req)
+	req = withRequestTimeout(req, b)
 
 	// Add additional headers.
 	for k, values := range c.HTTPHeaders() {
@@ -292,6 +479,22 @@ func newJSONRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 	return req, nil
 }
 
+// newAssistantsRequest creates a new HTTP request instance for the
+// Assistants API, which requires the OpenAI-Beta header.
+func newAssistantsRequest(
+	c Clienter,
+	m, u string,
+	b any,
+) (*http.Request, error) {
+	req, err := newJSONRequest(c, m, u, b)
+	if err != nil {
+		return req, err
+	}
+
+	req.Header.Set("OpenAI-Beta", assistantsBeta)
+	return req, nil
+}
+
 // newDataRequest is a helper function that creates a new
 // multipart/form-data HTTP request.
 // It takes a Clienter interface, HTTP method, URL, and request body as input.
@@ -316,12 +519,19 @@ func newDataRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 
 		// Skip fields without json tag
 		jsonTag := tag.Get("json")
-		if jsonTag == "" {
+		if jsonTag == "" || jsonTag == "-" {
 			continue
 		}
 
 		// Split the tag and use the first part (before omitempty, if present).
-		jsonFieldName := strings.Split(jsonTag, ",")[0]
+		tagParts := strings.Split(jsonTag, ",")
+		jsonFieldName := tagParts[0]
+
+		// Skip the zero values of the omitempty fields,
+		// so the API uses its defaults for them.
+		if field.IsZero() && g.In("omitempty", tagParts[1:]...) {
+			continue
+		}
 
 		if field.Type().String() == "*os.File" {
 			file, ok := field.Interface().(*os.File)
@@ -339,6 +549,26 @@ func newDataRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 					return &http.Request{}, err
 				}
 			}
+		} else if field.Kind() == reflect.Slice &&
+			field.Type().Elem().Kind() == reflect.Uint8 {
+			// The byte slices are sent as form files, the filename is
+			// set by the tag, e.g. `multipart:"blob,filename=image.png"`.
+			if field.Len() == 0 {
+				continue
+			}
+
+			fieldWriter, err := writer.CreateFormFile(
+				jsonFieldName,
+				multipartFilename(tag.Get("multipart"), jsonFieldName),
+			)
+			if err != nil {
+				return &http.Request{}, err
+			}
+
+			_, err = fieldWriter.Write(field.Bytes())
+			if err != nil {
+				return &http.Request{}, err
+			}
 		} else {
 			// Check if the field is of type string.
 			if field.Kind() == reflect.String {
@@ -346,6 +576,15 @@ func newDataRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 				if err != nil {
 					return &http.Request{}, err
 				}
+			} else if values, ok := field.Interface().([]string); ok {
+				// The array fields are sent as repeated
+				// fields, e.g. "timestamp_granularities[]".
+				for _, v := range values {
+					err := writer.WriteField(jsonFieldName, v)
+					if err != nil {
+						return &http.Request{}, err
+					}
+				}
 			} else {
 				jsonField, err := json.Marshal(field.Interface())
 				if err != nil {
@@ -371,55 +610,364 @@ func newDataRequest(c Clienter, m, u string, b any) (*http.Request, error) {
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey()))
-	if orgID := c.OrgID(); orgID != "" {
-		req.Header.Set("OpenAI-Organization", orgID)
+	setAuthHeaders(c, req)
+	req = withRequestTimeout(req, b)
+
+	// Report the progress of sending the body if the request asks for it.
+	// The body is wrapped on every retry as well, so the counter restarts.
+	if pr, ok := b.(progressReporter); ok && pr.progressFunc() != nil {
+		fn, total := pr.progressFunc(), req.ContentLength
+		req.Body = &progressReader{r: req.Body, total: total, fn: fn}
+		getBody := req.GetBody
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &progressReader{r: body, total: total, fn: fn}, nil
+		}
 	}
 
 	return req, err
 }
 
-// The doRequest performs an HTTP request and returns
-// the response body as a byte slice.
-func doRequest(
-	c Clienter,
-	req *http.Request,
-	goal any,
-) ([]byte, error) {
+// The multipartFilename returns the filename of the multipart tag,
+// e.g. "image.png" for "blob,filename=image.png", or the name if the
+// tag has no filename option.
+func multipartFilename(tag, name string) string {
+	for _, option := range strings.Split(tag, ",") {
+		if filename, ok := strings.CutPrefix(option, "filename="); ok {
+			return filename
+		}
+	}
+
+	return name
+}
+
+// The timeoutKey is the context key of the timeout of the request.
+type timeoutKey struct{}
+
+// The requestTimeouter is implemented by the
+// requests that have their own timeout.
+type requestTimeouter interface {
+	timeout() time.Duration
+}
+
+// The withRequestTimeout keeps the timeout of the request body b, if it
+// has one, in the context of the request. The context is limited by the
+// timeout when the request is sent, see sendRequest, so the timer isn't
+// started until then.
+func withRequestTimeout(req *http.Request, b any) *http.Request {
+	t, ok := b.(requestTimeouter)
+	if !ok || t.timeout() <= 0 {
+		return req
+	}
+
+	ctx := context.WithValue(req.Context(), timeoutKey{}, t.timeout())
+	return req.WithContext(ctx)
+}
+
+// The withContext returns a copy of the request with the ctx of the
+// caller, the timeout of the request is kept.
+func withContext(req *http.Request, ctx context.Context) *http.Request {
+	if d, ok := timeoutOf(req); ok {
+		ctx = context.WithValue(ctx, timeoutKey{}, d)
+	}
+
+	return req.WithContext(ctx)
+}
+
+// The timeoutOf returns the timeout of the request,
+// it returns false if the request has no timeout.
+func timeoutOf(req *http.Request) (time.Duration, bool) {
+	d, ok := req.Context().Value(timeoutKey{}).(time.Duration)
+	return d, ok && d > 0
+}
+
+// The httpClientFor returns the HTTP client to send the request.
+// The timeout of the request can be longer than the timeout of the
+// client, so the request with its own timeout is sent by a copy of
+// the client without the timeout and relies on the context only.
+func httpClientFor(c Clienter, req *http.Request) *http.Client {
+	httpClient := c.HTTPClient()
+	if _, ok := timeoutOf(req); !ok || httpClient.Timeout == 0 {
+		return httpClient
+	}
+
+	noTimeout := *httpClient
+	noTimeout.Timeout = 0
+	return &noTimeout
+}
+
+// The cancelOnClose releases the context
+// when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the context.
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// The progressReporter is implemented by the requests
+// that report the progress of sending their body.
+type progressReporter interface {
+	progressFunc() func(bytesWritten, totalBytes int64)
+}
+
+// The progressReader calls the fn with the number
+// of bytes read so far on every read of the body.
+type progressReader struct {
+	r     io.ReadCloser
+	n     int64
+	total int64
+	fn    func(bytesWritten, totalBytes int64)
+}
+
+// Read reads the body and reports the progress.
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.n += int64(n)
+		p.fn(p.n, p.total)
+	}
+
+	return n, err
+}
+
+// Close closes the body.
+func (p *progressReader) Close() error {
+	return p.r.Close()
+}
+
+// The sendRequest performs an HTTP request and returns the response
+// if its status code is successful. Otherwise, the response body is
+// read to get the error details and closed.
+func sendRequest(c Clienter, req *http.Request) (*http.Response, error) {
+	// The context of the request timeout is released when
+	// the response body is closed or the request fails.
+	if d, ok := timeoutOf(req); ok {
+		ctx, cancel := context.WithTimeout(req.Context(), d)
+		resp, err := sendRequestOnce(c, req.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	}
+
+	return sendRequestOnce(c, req)
+}
+
+// The sendRequestOnce performs the request like sendRequest,
+// but doesn't release the context of the request timeout.
+func sendRequestOnce(c Clienter, req *http.Request) (*http.Response, error) {
 	// Send request.
-	resp, err := c.HTTPClient().Do(req)
+	resp, err := sendWithRetry(c, req)
 	if err != nil {
 		netErr, ok := err.(net.Error)
 		if ok && netErr.Timeout() {
-			return []byte{}, ErrRequestTimedOut
+			return nil, ErrRequestTimedOut
 		}
-		return []byte{}, err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Check the HTTP status code.
 	if !isSuccessfulCode(resp.StatusCode) {
+		defer resp.Body.Close()
+
 		// Read the response errorBody.
-		errorBody, err := ioutil.ReadAll(resp.Body)
+		errorBody, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return []byte{}, fmt.Errorf("failed to read error body: %v", err)
+			return nil, fmt.Errorf("failed to read error body: %v", err)
 		}
 
 		errorResponse := ErrorResponse{}
 		json.Unmarshal(errorBody, &errorResponse)
 
 		// Return an error that includes the status code and the error details.
-		return []byte{}, fmt.Errorf(
-			"non-success status code %d: %s",
-			resp.StatusCode,
-			errorResponse.Error.Message,
-		)
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       errorResponse.Error.Code,
+			Type:       errorResponse.Error.Type,
+			Message:    errorResponse.Error.Message,
+			Param:      errorResponse.Error.Param,
+			Meta:       newResponseMeta(resp),
+		}
 	}
 
+	return resp, nil
+}
+
+// The retryPolicyProvider is implemented by the clients
+// that retry the failed requests, e.g. by the *Client.
+type retryPolicyProvider interface {
+	RetryPolicy() RetryPolicy
+}
+
+// The rateLimiterProvider is implemented by the clients
+// that throttle the requests, e.g. by the *Client.
+type rateLimiterProvider interface {
+	RateLimiter() RateLimiter
+}
+
+// The sendWithRetry sends the request and repeats it while the
+// client's retry policy allows it. The request body is restored
+// before each retry. The pending retry is aborted immediately
+// if the request context is cancelled.
+//
+// If the client has a rate limiter, each attempt waits for it with
+// the token count estimated from the size of the request body of
+// the text generation requests.
+func sendWithRetry(c Clienter, req *http.Request) (*http.Response, error) {
+	var policy RetryPolicy
+	if p, ok := c.(retryPolicyProvider); ok {
+		policy = p.RetryPolicy()
+	}
+
+	var limiter RateLimiter
+	if l, ok := c.(rateLimiterProvider); ok {
+		limiter = l.RateLimiter()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			err := limiter.Wait(req.Context(), requestTokens(req))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := httpClientFor(c, req).Do(req)
+		if policy == nil {
+			return resp, err
+		}
+
+		retry, delay := policy.ShouldRetry(attempt, resp, err)
+		if !retry || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		// Discard the failed response so that
+		// the connection can be reused.
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// The doStreamRequest performs an HTTP request and returns the
+// response body without reading it, so the data can be consumed
+// as it arrives. The caller is responsible for closing the body.
+func doStreamRequest(c Clienter, req *http.Request) (io.ReadCloser, error) {
+	resp, err := sendRequest(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// The readEventStream reads the server-sent events stream and calls
+// the fn for the data of each event. Reading stops when the stream
+// ends, the "[DONE]" sentinel is received, or fn returns an error.
+func readEventStream(r io.Reader, fn func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+
+		// Skip empty lines, comments and other fields of the event.
+		if !bytes.HasPrefix(line, []byte("data:")) {
+			continue
+		}
+
+		data := bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
+		if string(data) == "[DONE]" {
+			return nil
+		}
+
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// The doRequest performs an HTTP request and returns
+// the response body as a byte slice.
+func doRequest(
+	c Clienter,
+	req *http.Request,
+	goal any,
+) ([]byte, error) {
+	body, _, err := doRequestWithMeta(c, req, goal)
+	return body, err
+}
+
+// The doRequestWithMeta performs an HTTP request like doRequest
+// and also returns the metadata of the response.
+func doRequestWithMeta(
+	c Clienter,
+	req *http.Request,
+	goal any,
+) ([]byte, *ResponseMeta, error) {
+	// Trace the request if the client has a tracer.
+	if tp, ok := c.(tracerProvider); ok && tp.tracer() != nil {
+		return doTracedRequest(tp.tracer(), c, req, goal)
+	}
+
+	return sendAndDecode(c, req, goal)
+}
+
+// The sendAndDecode sends the request, reads the response body
+// and unmarshals it into the goal.
+func sendAndDecode(
+	c Clienter,
+	req *http.Request,
+	goal any,
+) ([]byte, *ResponseMeta, error) {
+	// Send request. The metadata of the failed response
+	// is returned with the *APIError.
+	resp, err := sendRequest(c, req)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return []byte{}, apiErr.Meta, err
+		}
+
+		return []byte{}, nil, err
+	}
+	defer resp.Body.Close()
+
 	// Read response body.
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, nil, err
 	}
 
 	// Unmarshal response body if goal is not nil and is a pointer to a struct.
@@ -428,9 +976,9 @@ func doRequest(
 		reflect.Indirect(reflect.ValueOf(goal)).Kind() == reflect.Struct {
 		err = json.Unmarshal(body, goal)
 		if err != nil {
-			return []byte{}, err
+			return []byte{}, nil, err
 		}
 	}
 
-	return body, nil
+	return body, newResponseMeta(resp), nil
 }
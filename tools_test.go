@@ -0,0 +1,227 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestSaveAtomic tests that the failed saving
+// of images leaves no temporary files behind.
+func TestSaveAtomic(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n"))
+
+	// The server breaks the download in the middle of the body.
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("\x89PNG"))
+		},
+	))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name     string
+		readOnly bool
+		save     func(path string) error
+	}{
+		{
+			name:     "read-only directory",
+			readOnly: true,
+			save: func(path string) error {
+				return saveByBase64(path, 1, []string{png})
+			},
+		},
+		{
+			name: "interrupted download",
+			save: func(path string) error {
+				return saveByURLWithContext(
+					context.Background(),
+					srv.Client(),
+					path,
+					1,
+					[]string{srv.URL},
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.readOnly {
+				if os.Geteuid() == 0 {
+					t.Skip("the root can write to a read-only directory")
+				}
+
+				if err := os.Chmod(dir, 0o555); err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { os.Chmod(dir, 0o755) })
+			}
+
+			path := filepath.Join(dir, "image.png")
+			if err := tt.save(path); err == nil {
+				t.Fatal("save() error = nil, want an error")
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, e := range entries {
+				t.Errorf("file %q is left in the directory", e.Name())
+			}
+		})
+	}
+}
+
+func TestGenerateUniqueFilename(t *testing.T) {
+	taken := fmt.Sprintf("%x", make([]byte, 16))
+
+	tests := []struct {
+		name     string
+		attempts int
+		fills    []byte
+		want     string
+		err      error
+	}{
+		{
+			name:     "free name",
+			attempts: 3,
+			fills:    []byte{1},
+			want:     fmt.Sprintf("%x", bytes.Repeat([]byte{1}, 16)),
+		},
+		{
+			name:     "retry after collision",
+			attempts: 3,
+			fills:    []byte{0, 0, 2},
+			want:     fmt.Sprintf("%x", bytes.Repeat([]byte{2}, 16)),
+		},
+		{
+			name:     "all attempts collide",
+			attempts: 3,
+			fills:    []byte{0, 0, 0, 3},
+			err:      ErrCannotGenerateUniqueFilename,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, taken+".png"), nil, 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Each call of the randRead fills the bytes
+			// with the next value of the fills.
+			calls := 0
+			defer func(read func([]byte) (int, error), n int) {
+				randRead, UniqueFilenameAttempts = read, n
+			}(randRead, UniqueFilenameAttempts)
+
+			UniqueFilenameAttempts = tt.attempts
+			randRead = func(b []byte) (int, error) {
+				copy(b, bytes.Repeat([]byte{tt.fills[calls]}, len(b)))
+				calls++
+				return len(b), nil
+			}
+
+			got, err := generateUniqueFilename(dir, ".png")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("generateUniqueFilename() error = %v, want %v",
+					err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Errorf("generateUniqueFilename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDataRequestOmitEmpty(t *testing.T) {
+	type request struct {
+		Model          string   `json:"model"`
+		Prompt         string   `json:"prompt"`
+		N              int      `json:"n,omitempty"`
+		Stream         bool     `json:"stream,omitempty"`
+		Temperature    *float64 `json:"temperature,omitempty"`
+		ResponseFormat string   `json:"response_format,omitempty"`
+		Size           int      `json:"size"`
+	}
+
+	temperature := 0.0
+	tests := []struct {
+		name string
+		r    request
+		want map[string]string
+	}{
+		{
+			name: "zero values",
+			r:    request{Model: "whisper-1"},
+			want: map[string]string{
+				"model":  "whisper-1",
+				"prompt": "",
+				"size":   "0",
+			},
+		},
+		{
+			name: "set values",
+			r: request{
+				Model:          "whisper-1",
+				N:              2,
+				Stream:         true,
+				Temperature:    &temperature,
+				ResponseFormat: "json",
+			},
+			want: map[string]string{
+				"model":           "whisper-1",
+				"prompt":          "",
+				"n":               "2",
+				"stream":          "true",
+				"temperature":     "0",
+				"response_format": "json",
+				"size":            "0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := newDataRequest(
+				New("test-key"),
+				http.MethodPost,
+				"https://x/v1",
+				&tt.r,
+			)
+			if err != nil {
+				t.Fatalf("newDataRequest() error = %v", err)
+			}
+
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("ParseMultipartForm() error = %v", err)
+			}
+
+			got := make(map[string]string, len(req.MultipartForm.Value))
+			for k, v := range req.MultipartForm.Value {
+				got[k] = v[0]
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("form = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
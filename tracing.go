@@ -0,0 +1,153 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// The modelRegexp finds the model in the JSON request body.
+var modelRegexp = regexp.MustCompile(`"model"\s*:\s*"([^"]*)"`)
+
+// Tracer starts the spans of the API calls. It is a subset of the
+// OpenTelemetry trace.Tracer, so the package doesn't depend on the
+// OpenTelemetry modules, and an adapter of a few lines is enough:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(
+//	    ctx context.Context,
+//	    name string,
+//	) (context.Context, openai.Span) {
+//	    ctx, span := t.Tracer.Start(ctx, name)
+//	    return ctx, otelSpan{span}
+//	}
+//
+//	client, err := openai.NewClient(
+//	    openai.WithAPIKey(key),
+//	    openai.WithTracer(otelTracer{otel.Tracer("openai")}),
+//	)
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is the span of a single API call.
+type Span interface {
+	// SetAttribute sets the attribute of the span,
+	// the value is a string or an int.
+	SetAttribute(key string, value any)
+
+	// SetError marks the span as failed with the error,
+	// e.g. sets the codes.Error status of the OpenTelemetry span.
+	SetError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// The tracerProvider is implemented by the clients
+// that trace the API calls, e.g. by the *Client.
+type tracerProvider interface {
+	tracer() Tracer
+}
+
+// The doTracedRequest performs the request like doRequestWithMeta
+// in the "openai.{operation}" span, e.g. "openai.chat/completions".
+// The span has the model, the endpoint, the status code and the
+// token usage of the response as attributes.
+func doTracedRequest(
+	t Tracer,
+	c Clienter,
+	req *http.Request,
+	goal any,
+) ([]byte, *ResponseMeta, error) {
+	operation := operationName(req.URL.Path)
+	ctx, span := t.Start(req.Context(), "openai."+operation)
+	defer span.End()
+
+	span.SetAttribute("endpoint", operation)
+	if model := requestModel(req); model != "" {
+		span.SetAttribute("model", model)
+	}
+
+	body, meta, err := sendAndDecode(c, withContext(req, ctx), goal)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttribute("http.status_code", apiErr.StatusCode)
+		}
+
+		span.SetError(err)
+		return body, meta, err
+	}
+
+	span.SetAttribute("http.status_code", meta.StatusCode())
+	if isJSON(meta.Headers()) {
+		scanUsage(body, func(tokenType string, n int) {
+			span.SetAttribute("llm.usage."+tokenType+"_tokens", n)
+		})
+	}
+
+	return body, meta, nil
+}
+
+// The operationSegments are the static segments of the endpoints,
+// the other segments of the path are the IDs and the names.
+var operationSegments = map[string]bool{
+	"assistants": true, "audio": true, "batches": true, "cancel": true,
+	"chat": true, "completions": true, "content": true, "deployments": true,
+	"edits": true, "embeddings": true, "events": true, "files": true,
+	"fine-tunes": true, "fine_tuning": true, "generations": true,
+	"images": true, "jobs": true, "messages": true, "models": true,
+	"moderations": true, "openai": true, "runs": true, "speech": true,
+	"submit_tool_outputs": true, "threads": true, "transcriptions": true,
+	"translations": true, "variations": true,
+}
+
+// The operationName returns the path of the endpoint without the API
+// version, e.g. "chat/completions". The IDs and the names in the path
+// are replaced with the placeholder, e.g. "files/{id}/content", to keep
+// the number of the operations small.
+func operationName(path string) string {
+	if i := strings.Index(path, "/v1/"); i >= 0 {
+		path = path[i+len("/v1/"):]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "" && !operationSegments[segment] {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// The requestModel returns the model of the JSON request body,
+// or an empty string if the body can't be read again.
+func requestModel(req *http.Request) string {
+	if req.GetBody == nil ||
+		!strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	if m := modelRegexp.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+
+	return ""
+}
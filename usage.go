@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"strings"
+	"sync"
+)
+
+// PricingUpdated is the date the model prices of EstimateCost
+// were last checked against the OpenAI pricing page.
+const PricingUpdated = "2024-08-06"
+
+// The modelPrice is the price in USD per one million tokens.
+type modelPrice struct {
+	prompt     float64
+	completion float64
+}
+
+// The modelPrices are the prices of the models by the model ID prefix.
+var modelPrices = map[string]modelPrice{
+	"gpt-4o":                 {2.50, 10.00},
+	"gpt-4o-mini":            {0.15, 0.60},
+	"gpt-4-turbo":            {10.00, 30.00},
+	"gpt-4":                  {30.00, 60.00},
+	"gpt-3.5-turbo":          {0.50, 1.50},
+	"davinci-002":            {2.00, 2.00},
+	"babbage-002":            {0.40, 0.40},
+	"text-embedding-3-small": {0.02, 0},
+	"text-embedding-3-large": {0.13, 0},
+	"text-embedding-ada-002": {0.10, 0},
+}
+
+// AggregatedUsage is the total usage of many requests.
+type AggregatedUsage struct {
+	PromptTokens     int // prompt tokens of all requests
+	CompletionTokens int // completion tokens of all requests
+	TotalTokens      int // total tokens of all requests
+	Requests         int // number of requests
+}
+
+// UsageAccumulator sums up the token usage of many requests.
+// It is safe for concurrent use, the zero value is ready to use.
+//
+// Example usage:
+//
+//	var usage openai.UsageAccumulator
+//	resp, err := client.ChatCompletion(request)
+//	if err == nil {
+//	    usage.AddChat(resp.Usage)
+//	}
+//	fmt.Printf("$%.4f\n", usage.EstimateCost("gpt-4o"))
+type UsageAccumulator struct {
+	mu    sync.Mutex
+	total AggregatedUsage
+}
+
+// Add adds the usage of the completion request.
+func (a *UsageAccumulator) Add(u CompletionUsage) {
+	a.add(u.PromptTokens, u.CompletionTokens, u.TotalTokens)
+}
+
+// AddChat adds the usage of the chat completion request.
+func (a *UsageAccumulator) AddChat(u ChatCompletionUsage) {
+	a.add(u.PromptTokens, u.CompletionTokens, u.TotalTokens)
+}
+
+// AddEmbedding adds the usage of the embedding request.
+func (a *UsageAccumulator) AddEmbedding(u EmbeddingUsage) {
+	a.add(u.PromptTokens, 0, u.TotalTokens)
+}
+
+// Total returns the total usage of the added requests.
+func (a *UsageAccumulator) Total() AggregatedUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// Reset sets the total usage to zero.
+func (a *UsageAccumulator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total = AggregatedUsage{}
+}
+
+// EstimateCost returns the estimated cost in USD of the total usage
+// if all requests were sent to the model. The model is matched by
+// the longest known prefix, so the dated versions of the models are
+// priced as well. It returns 0 for the unknown models.
+func (a *UsageAccumulator) EstimateCost(model string) float64 {
+	price, ok := findModelPrice(model)
+	if !ok {
+		return 0
+	}
+
+	total := a.Total()
+	return (float64(total.PromptTokens)*price.prompt +
+		float64(total.CompletionTokens)*price.completion) / 1e6
+}
+
+// The add adds the tokens of a single request.
+func (a *UsageAccumulator) add(prompt, completion, total int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total.PromptTokens += prompt
+	a.total.CompletionTokens += completion
+	a.total.TotalTokens += total
+	a.total.Requests++
+}
+
+// The findModelPrice returns the price of the model
+// with the longest prefix that matches the model ID.
+func findModelPrice(model string) (modelPrice, bool) {
+	var price modelPrice
+	prefix := ""
+	for p, v := range modelPrices {
+		if strings.HasPrefix(model, p) && len(p) > len(prefix) {
+			price, prefix = v, p
+		}
+	}
+
+	return price, prefix != ""
+}